@@ -0,0 +1,165 @@
+package iso8601
+
+import (
+	"time"
+)
+
+// carry-over points of alternative duration format.
+const (
+	maxAlternativeYears   = 9999
+	maxAlternativeMonths  = 12
+	maxAlternativeDays    = 30
+	maxAlternativeHours   = 24
+	maxAlternativeMinutes = 60
+	maxAlternativeSeconds = 60
+)
+
+// leadingDigits returns count of leading [0-9] in s.
+func leadingDigits(s string) int {
+	var i int
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// isAlternativeDuration reports whether s (without leading P) is in alternative format.
+func isAlternativeDuration(s string) bool {
+	var n = leadingDigits(s)
+	return n == 4 && len(s) > 4 && s[4] == '-' ||
+		n == 8 && (len(s) == 8 || s[8] == 'T')
+}
+
+// fixedInt consumes exactly n digits from s.
+func fixedInt(s string, n int) (x int64, rem string, ok bool) {
+	if len(s) < n {
+		return 0, s, false
+	}
+	for i := 0; i < n; i++ {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return 0, s, false
+		}
+		x = x*10 + int64(c) - '0'
+	}
+	return x, s[n:], true
+}
+
+// unexpectedAt returns error position for the first character of s.
+func unexpectedAt(body, s string) (offset, length int, reason Reason) {
+	if s == "" {
+		return len(body), 0, ReasonUnexpectedEnd
+	}
+	return len(body) - len(s), 1, ReasonUnexpectedCharacter
+}
+
+// parseAlternativeFields parse fixed width fields separated by sep (when extended).
+func parseAlternativeFields(body, s string, fields []*int64, widths []int, maxes []int64, sep byte, extended bool) (rem string, offset, length int, reason Reason) {
+	for i, v := range fields {
+		if i > 0 && extended {
+			if s == "" || s[0] != sep {
+				offset, length, reason = unexpectedAt(body, s)
+				return
+			}
+			s = s[1:]
+		}
+		var ok bool
+		var start = s
+		*v, s, ok = fixedInt(s, widths[i])
+		if !ok {
+			offset, length, reason = unexpectedAt(body, s[leadingDigits(s):])
+			return
+		}
+		if *v > maxes[i] {
+			return s, len(body) - len(start), widths[i], ReasonOutOfRange
+		}
+	}
+	return s, 0, 0, ReasonUnknown
+}
+
+// parseAlternativeDuration parse duration in alternative format,
+// extended PYYYY-MM-DDThh:mm:ss or basic PYYYYMMDDThhmmss,
+// s is the part after P.
+// It returns error position relative to s.
+func parseAlternativeDuration(s string, ret *Duration) (offset, length int, reason Reason) {
+	var body = s
+	var extended = s[4] == '-'
+	s, offset, length, reason = parseAlternativeFields(
+		body, s,
+		[]*int64{&ret.Years, &ret.Months, &ret.Days},
+		[]int{4, 2, 2},
+		[]int64{maxAlternativeYears, maxAlternativeMonths, maxAlternativeDays},
+		'-', extended,
+	)
+	if reason != ReasonUnknown || s == "" {
+		return
+	}
+	if s[0] != 'T' {
+		return unexpectedAt(body, s)
+	}
+	s = s[1:]
+	s, offset, length, reason = parseAlternativeFields(
+		body, s,
+		[]*int64{&ret.Hours, &ret.Minutes, &ret.Seconds},
+		[]int{2, 2, 2},
+		[]int64{maxAlternativeHours, maxAlternativeMinutes, maxAlternativeSeconds},
+		':', extended,
+	)
+	if reason != ReasonUnknown {
+		return
+	}
+	if s != "" && (s[0] == '.' || s[0] == ',') {
+		s = s[1:]
+		var digits = s[:leadingDigits(s)]
+		if digits == "" {
+			return len(body) - len(s) - 1, 1, ReasonMissingDigits
+		}
+		s = s[len(digits):]
+		ret.Nanoseconds = fractionOf(digits, time.Second)
+	}
+	if s != "" {
+		return unexpectedAt(body, s)
+	}
+	return
+}
+
+// fitsAlternative reports whether d can be represented in alternative format.
+func (d Duration) fitsAlternative() bool {
+	return d.Weeks == 0 &&
+		d.Years >= 0 && d.Years <= maxAlternativeYears &&
+		d.Months >= 0 && d.Months <= maxAlternativeMonths &&
+		d.Days >= 0 && d.Days <= maxAlternativeDays &&
+		d.Hours >= 0 && d.Hours <= maxAlternativeHours &&
+		d.Minutes >= 0 && d.Minutes <= maxAlternativeMinutes &&
+		d.Seconds >= 0 && d.Seconds <= maxAlternativeSeconds &&
+		d.Nanoseconds >= 0
+}
+
+// appendAlternative append d in alternative format, d must fits.
+func (d Duration) appendAlternative(b []byte, opts FormatOptions) []byte {
+	if d.Negative {
+		b = append(b, '-')
+	}
+	b = append(b, 'P')
+	b = appendInt(b, d.Years, 4)
+	if !opts.Basic {
+		b = append(b, '-')
+	}
+	b = appendInt(b, d.Months, 2)
+	if !opts.Basic {
+		b = append(b, '-')
+	}
+	b = appendInt(b, d.Days, 2)
+	b = append(b, 'T')
+	b = appendInt(b, d.Hours, 2)
+	if !opts.Basic {
+		b = append(b, ':')
+	}
+	b = appendInt(b, d.Minutes, 2)
+	if !opts.Basic {
+		b = append(b, ':')
+	}
+	b = appendInt(b, d.Seconds, 2)
+	b = opts.appendFraction(b, uint64(d.Nanoseconds), uint64(time.Second))
+	return b
+}
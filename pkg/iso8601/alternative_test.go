@@ -0,0 +1,114 @@
+package iso8601
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDurationAlternative(t *testing.T) {
+	for _, c := range []struct {
+		s        string
+		expected Duration
+		err      error
+	}{
+		{s: "P0003-06-04T12:30:05", expected: Duration{Years: 3, Months: 6, Days: 4, Hours: 12, Minutes: 30, Seconds: 5}},
+		{s: "P00030604T123005", expected: Duration{Years: 3, Months: 6, Days: 4, Hours: 12, Minutes: 30, Seconds: 5}},
+		{s: "P0003-06-04", expected: Duration{Years: 3, Months: 6, Days: 4}},
+		{s: "P00030604", expected: Duration{Years: 3, Months: 6, Days: 4}},
+		{s: "P0000-00-00T00:00:00.5", expected: Duration{Nanoseconds: 5e8}},
+		{s: "P00000000T000000.000001", expected: Duration{Nanoseconds: 1e3}},
+		{s: "P0000-00-00T00:00:00,25", expected: Duration{Nanoseconds: 25e7}},
+		{s: "-P0001-00-00T00:00:00", expected: Duration{Years: 1, Negative: true}},
+		{s: "P9999-12-30T24:60:60", expected: Duration{Years: 9999, Months: 12, Days: 30, Hours: 24, Minutes: 60, Seconds: 60}},
+		{s: "P0000-13-00T00:00:00", err: ErrInvalidDuration{String: "P0000-13-00T00:00:00", Offset: 6, Token: "13", Reason: ReasonOutOfRange}},
+		{s: "P0000-00-31T00:00:00", err: ErrInvalidDuration{String: "P0000-00-31T00:00:00", Offset: 9, Token: "31", Reason: ReasonOutOfRange}},
+		{s: "P0000-00-00T25:00:00", err: ErrInvalidDuration{String: "P0000-00-00T25:00:00", Offset: 12, Token: "25", Reason: ReasonOutOfRange}},
+		{s: "P0000-00-00T00:61:00", err: ErrInvalidDuration{String: "P0000-00-00T00:61:00", Offset: 15, Token: "61", Reason: ReasonOutOfRange}},
+		{s: "P0000-00-00T00:00:61", err: ErrInvalidDuration{String: "P0000-00-00T00:00:61", Offset: 18, Token: "61", Reason: ReasonOutOfRange}},
+		{s: "P0003-0604", err: ErrInvalidDuration{String: "P0003-0604", Offset: 8, Token: "0", Reason: ReasonUnexpectedCharacter}},
+		{s: "P0003-6-04", err: ErrInvalidDuration{String: "P0003-6-04", Offset: 7, Token: "-", Reason: ReasonUnexpectedCharacter}},
+		{s: "P0003-06-04X", err: ErrInvalidDuration{String: "P0003-06-04X", Offset: 11, Token: "X", Reason: ReasonUnexpectedCharacter}},
+		{s: "P0003-06-04T", err: ErrInvalidDuration{String: "P0003-06-04T", Offset: 12, Token: "", Reason: ReasonUnexpectedEnd}},
+		{s: "P0003-06-04T123005", err: ErrInvalidDuration{String: "P0003-06-04T123005", Offset: 14, Token: "3", Reason: ReasonUnexpectedCharacter}},
+		{s: "P00030604T12:30:05", err: ErrInvalidDuration{String: "P00030604T12:30:05", Offset: 12, Token: ":", Reason: ReasonUnexpectedCharacter}},
+		{s: "P0003-06-04T12:30", err: ErrInvalidDuration{String: "P0003-06-04T12:30", Offset: 17, Token: "", Reason: ReasonUnexpectedEnd}},
+		{s: "P0003-06-04T12:30:05.", err: ErrInvalidDuration{String: "P0003-06-04T12:30:05.", Offset: 20, Token: ".", Reason: ReasonMissingDigits}},
+		{s: "P0003-06-04T12:30:05Z", err: ErrInvalidDuration{String: "P0003-06-04T12:30:05Z", Offset: 20, Token: "Z", Reason: ReasonUnexpectedCharacter}},
+		// designator format is not affected.
+		{s: "P0003Y", expected: Duration{Years: 3}},
+		{s: "P00030604D", expected: Duration{Days: 30604}},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseDuration(c.s)
+			require.Equal(t, c.err, err)
+			if err == nil {
+				assert.Equal(t, c.expected, v)
+			}
+		})
+	}
+}
+
+func TestDurationAppendFormatAlternative(t *testing.T) {
+	for _, c := range []struct {
+		duration Duration
+		options  []FormatOption
+		expected string
+	}{
+		{duration: Duration{}, expected: "P0000-00-00T00:00:00"},
+		{
+			duration: Duration{Years: 3, Months: 6, Days: 4, Hours: 12, Minutes: 30, Seconds: 5},
+			expected: "P0003-06-04T12:30:05",
+		},
+		{
+			duration: Duration{Years: 3, Months: 6, Days: 4, Hours: 12, Minutes: 30, Seconds: 5},
+			options:  []FormatOption{FormatOptionBasic()},
+			expected: "P00030604T123005",
+		},
+		{
+			duration: Duration{Seconds: 5, Nanoseconds: 25e7, Negative: true},
+			expected: "-P0000-00-00T00:00:05.25",
+		},
+		{
+			duration: Duration{Seconds: 5, Nanoseconds: 25e7},
+			options:  []FormatOption{FormatOptionComma(), FormatOptionBasic()},
+			expected: "P00000000T000005,25",
+		},
+		{
+			duration: Duration{Years: 9999, Months: 12, Days: 30, Hours: 24, Minutes: 60, Seconds: 60},
+			expected: "P9999-12-30T24:60:60",
+		},
+		// not fits
+		{duration: Duration{Weeks: 1}, expected: "P1W"},
+		{duration: Duration{Years: 10000}, expected: "P10000Y"},
+		{duration: Duration{Months: 13}, expected: "P13M"},
+		{duration: Duration{Days: 31}, expected: "P31D"},
+		{duration: Duration{Hours: 25}, expected: "PT25H"},
+		{duration: Duration{Minutes: 61}, expected: "PT61M"},
+		{duration: Duration{Seconds: 61}, expected: "PT61S"},
+		{duration: Duration{Days: -1}, expected: "P-1D"},
+		{duration: Duration{Seconds: 1, Nanoseconds: -1}, expected: "PT0.999999999S"},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			var options = append([]FormatOption{FormatOptionAlternative()}, c.options...)
+			var v = string(c.duration.AppendFormat(nil, options...))
+			assert.Equal(t, c.expected, v)
+			if c.duration.fitsAlternative() {
+				d, err := ParseDuration(v)
+				require.NoError(t, err)
+				assert.Equal(t, c.duration, d)
+			}
+		})
+	}
+}
+
+func BenchmarkParseDurationAlternative(b *testing.B) {
+	x := "P0003-06-04T12:30:05.5"
+	for i := 0; i < b.N; i++ {
+		_, err := ParseDuration(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package iso8601
+
+import "time"
+
+// carryNano keep nanoseconds in range by carry into seconds.
+func carryNano(d *Duration) (err error) {
+	if d.Nanoseconds < int64(time.Second) && d.Nanoseconds > -int64(time.Second) {
+		return nil
+	}
+	d.Seconds, err = addInt(d.Seconds, d.Nanoseconds/int64(time.Second))
+	if err != nil {
+		return
+	}
+	d.Nanoseconds %= int64(time.Second)
+	return
+}
+
+// Add returns component-wise sum of d and o,
+// Negative flag is folded into fields so result is never Negative.
+func (d Duration) Add(o Duration) (ret Duration, err error) {
+	d, err = d.signed()
+	if err != nil {
+		return Duration{}, err
+	}
+	o, err = o.signed()
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Years, err = addInt(d.Years, o.Years)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Months, err = addInt(d.Months, o.Months)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Weeks, err = addInt(d.Weeks, o.Weeks)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Days, err = addInt(d.Days, o.Days)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Hours, err = addInt(d.Hours, o.Hours)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Minutes, err = addInt(d.Minutes, o.Minutes)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Seconds, err = addInt(d.Seconds, o.Seconds)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Nanoseconds = d.Nanoseconds + o.Nanoseconds
+	err = carryNano(&ret)
+	if err != nil {
+		return Duration{}, err
+	}
+	return
+}
+
+// Sub returns component-wise difference of d and o, see Add.
+func (d Duration) Sub(o Duration) (Duration, error) {
+	return d.Add(o.Neg())
+}
+
+// Neg returns d with Negative flag toggled.
+func (d Duration) Neg() Duration {
+	d.Negative = !d.Negative
+	return d
+}
+
+// Mul returns d with every component multiplied by n,
+// Negative flag is folded into fields so result is never Negative.
+func (d Duration) Mul(n int64) (ret Duration, err error) {
+	d, err = d.signed()
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Years, err = multiplyInt(d.Years, n)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Months, err = multiplyInt(d.Months, n)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Weeks, err = multiplyInt(d.Weeks, n)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Days, err = multiplyInt(d.Days, n)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Hours, err = multiplyInt(d.Hours, n)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Minutes, err = multiplyInt(d.Minutes, n)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Seconds, err = multiplyInt(d.Seconds, n)
+	if err != nil {
+		return Duration{}, err
+	}
+
+	// nanoseconds * n = nanoseconds * (high * time.Second + low)
+	var high, low = n / int64(time.Second), n % int64(time.Second)
+	var carry int64
+	carry, err = multiplyInt(d.Nanoseconds, high)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Seconds, err = addInt(ret.Seconds, carry)
+	if err != nil {
+		return Duration{}, err
+	}
+	ret.Nanoseconds = d.Nanoseconds * low
+	err = carryNano(&ret)
+	if err != nil {
+		return Duration{}, err
+	}
+	return
+}
@@ -0,0 +1,147 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationAdd(t *testing.T) {
+	for _, c := range []struct {
+		a, b     Duration
+		expected Duration
+		err      error
+	}{
+		{expected: Duration{}},
+		{
+			a:        Duration{Years: 1, Months: 2, Weeks: 3, Days: 4, Hours: 5, Minutes: 6, Seconds: 7, Nanoseconds: 8},
+			b:        Duration{Years: 1, Months: 1, Weeks: 1, Days: 1, Hours: 1, Minutes: 1, Seconds: 1, Nanoseconds: 1},
+			expected: Duration{Years: 2, Months: 3, Weeks: 4, Days: 5, Hours: 6, Minutes: 7, Seconds: 8, Nanoseconds: 9},
+		},
+		{
+			a:        Duration{Days: 30},
+			b:        Duration{Days: 7, Negative: true},
+			expected: Duration{Days: 23},
+		},
+		{
+			a:        Duration{Days: 1, Negative: true},
+			b:        Duration{Hours: 1, Negative: true},
+			expected: Duration{Days: -1, Hours: -1},
+		},
+		{
+			a:        Duration{Seconds: 1, Nanoseconds: 6e8},
+			b:        Duration{Nanoseconds: 7e8},
+			expected: Duration{Seconds: 2, Nanoseconds: 3e8},
+		},
+		{
+			a:        Duration{Seconds: 1, Nanoseconds: 6e8},
+			b:        Duration{Nanoseconds: 7e8, Negative: true},
+			expected: Duration{Seconds: 1, Nanoseconds: -1e8},
+		},
+		{
+			a:        Duration{Nanoseconds: -6e8},
+			b:        Duration{Nanoseconds: -7e8},
+			expected: Duration{Seconds: -1, Nanoseconds: -3e8},
+		},
+		{
+			a:        Duration{Days: 1},
+			b:        Duration{Days: minInt64 + 1},
+			expected: Duration{Days: minInt64 + 2},
+		},
+		{a: Duration{Years: maxInt64}, b: Duration{Years: 1}, err: ErrOverflow},
+		{a: Duration{Months: minInt64}, b: Duration{Months: -1}, err: ErrOverflow},
+		{a: Duration{Hours: minInt64, Negative: true}, err: ErrOverflow},
+		{a: Duration{Seconds: maxInt64, Nanoseconds: 6e8}, b: Duration{Nanoseconds: 6e8}, err: ErrOverflow},
+	} {
+		t.Run(c.a.String()+"+"+c.b.String(), func(t *testing.T) {
+			v, err := c.a.Add(c.b)
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+}
+
+func TestDurationSub(t *testing.T) {
+	for _, c := range []struct {
+		a, b     Duration
+		expected Duration
+		err      error
+	}{
+		{expected: Duration{}},
+		{
+			a:        Duration{Months: 1, Days: 1},
+			b:        Duration{Days: 2},
+			expected: Duration{Months: 1, Days: -1},
+		},
+		{
+			a:        Duration{Hours: 1},
+			b:        Duration{Minutes: 1, Negative: true},
+			expected: Duration{Hours: 1, Minutes: 1},
+		},
+		{
+			a:        Duration{Nanoseconds: 1},
+			b:        Duration{Seconds: 1},
+			expected: Duration{Seconds: -1, Nanoseconds: 1},
+		},
+		{a: Duration{Weeks: minInt64}, b: Duration{Weeks: 1}, err: ErrOverflow},
+	} {
+		t.Run(c.a.String()+"-"+c.b.String(), func(t *testing.T) {
+			v, err := c.a.Sub(c.b)
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+}
+
+func TestDurationNeg(t *testing.T) {
+	assert.Equal(t, Duration{Days: 1, Negative: true}, Duration{Days: 1}.Neg())
+	assert.Equal(t, Duration{Days: 1}, Duration{Days: 1, Negative: true}.Neg())
+	assert.Equal(t, Duration{Days: minInt64, Negative: true}, Duration{Days: minInt64}.Neg())
+}
+
+func TestDurationMul(t *testing.T) {
+	for _, c := range []struct {
+		d        Duration
+		n        int64
+		expected Duration
+		err      error
+	}{
+		{d: Duration{Days: 1}, n: 0, expected: Duration{}},
+		{d: Duration{Days: 1}, n: 1, expected: Duration{Days: 1}},
+		{d: Duration{Days: 1}, n: -1, expected: Duration{Days: -1}},
+		{d: Duration{Days: 1, Negative: true}, n: -3, expected: Duration{Days: 3}},
+		{
+			d:        Duration{Years: 1, Months: 2, Weeks: 3, Days: 4, Hours: 5, Minutes: 6, Seconds: 7, Nanoseconds: 8},
+			n:        2,
+			expected: Duration{Years: 2, Months: 4, Weeks: 6, Days: 8, Hours: 10, Minutes: 12, Seconds: 14, Nanoseconds: 16},
+		},
+		{d: Duration{Nanoseconds: 5e8}, n: 3, expected: Duration{Seconds: 1, Nanoseconds: 5e8}},
+		{d: Duration{Nanoseconds: 5e8}, n: -3, expected: Duration{Seconds: -1, Nanoseconds: -5e8}},
+		{d: Duration{Nanoseconds: 1}, n: maxInt64, expected: Duration{Seconds: maxInt64 / int64(time.Second), Nanoseconds: maxInt64 % int64(time.Second)}},
+		{d: Duration{Nanoseconds: 999999999}, n: 3e9 + 7, expected: Duration{Seconds: 2999999997 + 6, Nanoseconds: 999999993}},
+		{d: Duration{Years: maxInt64/2 + 1}, n: 2, err: ErrOverflow},
+		{d: Duration{Years: minInt64}, n: -1, err: ErrOverflow},
+		{d: Duration{Seconds: maxInt64, Nanoseconds: 5e8}, n: 1, expected: Duration{Seconds: maxInt64, Nanoseconds: 5e8}},
+		{d: Duration{Seconds: maxInt64 / 2, Nanoseconds: 5e8}, n: 2, expected: Duration{Seconds: maxInt64}},
+		{d: Duration{Seconds: maxInt64 / 3, Nanoseconds: 9e8}, n: 3, err: ErrOverflow},
+	} {
+		t.Run(c.d.String(), func(t *testing.T) {
+			v, err := c.d.Mul(c.n)
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+}
+
+func BenchmarkDurationAdd(b *testing.B) {
+	x := Duration{Years: 1, Months: 2, Weeks: 3, Days: 4, Hours: 5, Minutes: 6, Seconds: 7, Nanoseconds: 8e8}
+	y := Duration{Years: 1, Months: 2, Weeks: 3, Days: 4, Hours: 5, Minutes: 6, Seconds: 7, Nanoseconds: 8e8, Negative: true}
+	for i := 0; i < b.N; i++ {
+		_, err := x.Add(y)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
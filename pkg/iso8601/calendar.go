@@ -0,0 +1,422 @@
+package iso8601
+
+import (
+	"errors"
+	"time"
+)
+
+// MonthEndPolicy decide what to do when adding years or months
+// results a day that not exists in target month (e.g. January 31 plus one month).
+type MonthEndPolicy int
+
+const (
+	// MonthEndOverflow normalize the day into next month like time.Time.AddDate
+	// (e.g. January 31 plus one month is March 2 or 3).
+	MonthEndOverflow MonthEndPolicy = iota
+	// MonthEndClamp use last day of target month
+	// (e.g. January 31 plus one month is February 28 or 29).
+	MonthEndClamp
+	// MonthEndReject return ErrMonthEnd.
+	MonthEndReject
+)
+
+// ErrMonthEnd returned when MonthEndReject policy used and
+// day not exists in target month.
+var ErrMonthEnd = errors.New("iso8601: day out of range for month")
+
+// AddOptions for Duration.AddTo
+type AddOptions struct {
+	MonthEnd MonthEndPolicy
+}
+
+// AddOption mutate AddOptions
+type AddOption func(opts *AddOptions)
+
+// AddOptionMonthEnd set policy for day that not exists in target month,
+// defaults to MonthEndOverflow.
+func AddOptionMonthEnd(policy MonthEndPolicy) AddOption {
+	return func(opts *AddOptions) {
+		opts.MonthEnd = policy
+	}
+}
+
+// signed returns duration that has Negative flag folded into fields.
+func (d Duration) signed() (ret Duration, err error) {
+	if !d.Negative {
+		return d, nil
+	}
+	ret.Years, err = negateInt(d.Years)
+	if err != nil {
+		return
+	}
+	ret.Months, err = negateInt(d.Months)
+	if err != nil {
+		return
+	}
+	ret.Weeks, err = negateInt(d.Weeks)
+	if err != nil {
+		return
+	}
+	ret.Days, err = negateInt(d.Days)
+	if err != nil {
+		return
+	}
+	ret.Hours, err = negateInt(d.Hours)
+	if err != nil {
+		return
+	}
+	ret.Minutes, err = negateInt(d.Minutes)
+	if err != nil {
+		return
+	}
+	ret.Seconds, err = negateInt(d.Seconds)
+	if err != nil {
+		return
+	}
+	ret.Nanoseconds = -d.Nanoseconds
+	return
+}
+
+// clockNano returns nanoseconds of hours, minutes, seconds and nanoseconds.
+func (d Duration) clockNano() (nano int64, err error) {
+	nano, err = addNano(nano, d.Hours, time.Hour)
+	if err != nil {
+		return
+	}
+	nano, err = addNano(nano, d.Minutes, time.Minute)
+	if err != nil {
+		return
+	}
+	nano, err = addNano(nano, d.Seconds, time.Second)
+	if err != nil {
+		return
+	}
+	return addNano(nano, d.Nanoseconds, time.Nanosecond)
+}
+
+// toInt handle overflow when convert int64 to int
+func toInt(v int64) (int, error) {
+	var ret = int(v)
+	if int64(ret) != v {
+		return 0, ErrOverflow
+	}
+	return ret, nil
+}
+
+// maxYear limits absolute year of calendar arithmetic result,
+// time.Time wraps around silently beyond year 292277026596.
+const maxYear = 1e11
+
+// checkYear returns ErrOverflow when year is out of maxYear.
+func checkYear(year int64) error {
+	if year > maxYear || year < -maxYear {
+		return ErrOverflow
+	}
+	return nil
+}
+
+// addDate is t.AddDate with overflow checked,
+// result year is estimated from years, months and days before adding.
+func addDate(t time.Time, years, months, days int64) (time.Time, error) {
+	year, err := addInt(int64(t.Year()), years)
+	if err != nil {
+		return time.Time{}, err
+	}
+	year, err = addInt(year, months/12)
+	if err != nil {
+		return time.Time{}, err
+	}
+	year, err = addInt(year, days/365)
+	if err != nil {
+		return time.Time{}, err
+	}
+	err = checkYear(year)
+	if err != nil {
+		return time.Time{}, err
+	}
+	y, err := toInt(years)
+	if err != nil {
+		return time.Time{}, err
+	}
+	m, err := toInt(months)
+	if err != nil {
+		return time.Time{}, err
+	}
+	d, err := toInt(days)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.AddDate(y, m, d), nil
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// addMonths add months to t with month end policy applied.
+func addMonths(t time.Time, months int64, policy MonthEndPolicy) (time.Time, error) {
+	if policy == MonthEndOverflow {
+		return addDate(t, 0, months, 0)
+	}
+	var year, month, day = t.Date()
+	var index, err = addInt(int64(month)-1, months)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var years = index / 12
+	index %= 12
+	if index < 0 {
+		index += 12
+		years--
+	}
+	years, err = addInt(int64(year), years)
+	if err != nil {
+		return time.Time{}, err
+	}
+	err = checkYear(years)
+	if err != nil {
+		return time.Time{}, err
+	}
+	year, err = toInt(years)
+	if err != nil {
+		return time.Time{}, err
+	}
+	month = time.Month(index + 1)
+	if n := daysIn(year, month); day > n {
+		if policy == MonthEndReject {
+			return time.Time{}, ErrMonthEnd
+		}
+		day = n
+	}
+	var hour, min, sec = t.Clock()
+	return time.Date(year, month, day, hour, min, sec, t.Nanosecond(), t.Location()), nil
+}
+
+// AddTo returns t plus d.
+//
+// Years, months, weeks and days are added on the calendar in location of t
+// one by one in that order, like time.Time.AddDate,
+// so wall clock is kept across DST changes and leap years are respected.
+// Month end policy (see AddOptionMonthEnd) is applied after each of them.
+// Hours, minutes, seconds and nanoseconds are added as elapsed time.
+//
+// Returns ErrOverflow when result is too far for time.Time.
+func (d Duration) AddTo(t time.Time, options ...AddOption) (time.Time, error) {
+	var opts = new(AddOptions)
+	for _, i := range options {
+		i(opts)
+	}
+
+	var s, err = d.signed()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Y
+	if s.Years != 0 {
+		if opts.MonthEnd == MonthEndOverflow {
+			t, err = addDate(t, s.Years, 0, 0)
+		} else {
+			var months int64
+			months, err = multiplyInt(12, s.Years)
+			if err != nil {
+				return time.Time{}, err
+			}
+			t, err = addMonths(t, months, opts.MonthEnd)
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	// M
+	if s.Months != 0 {
+		t, err = addMonths(t, s.Months, opts.MonthEnd)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	// W
+	if s.Weeks != 0 {
+		var days int64
+		days, err = multiplyInt(7, s.Weeks)
+		if err != nil {
+			return time.Time{}, err
+		}
+		t, err = addDate(t, 0, 0, days)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	// D
+	if s.Days != 0 {
+		t, err = addDate(t, 0, 0, s.Days)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	// T
+	var nano int64
+	nano, err = s.clockNano()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Add(time.Duration(nano)), nil
+}
+
+// MustAddTo execute AddTo and panic if error.
+func (d Duration) MustAddTo(t time.Time, options ...AddOption) time.Time {
+	var ret, err = d.AddTo(t, options...)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// BetweenOptions for Between.
+type BetweenOptions struct {
+	Units    Unit
+	Location *time.Location
+}
+
+// BetweenOption mutate BetweenOptions
+type BetweenOption func(opts *BetweenOptions)
+
+// BetweenOptionUnits set units that result can use,
+// defaults to all units except weeks.
+// Remainder smaller than the smallest unit is dropped.
+func BetweenOptionUnits(units Unit) BetweenOption {
+	return func(opts *BetweenOptions) {
+		opts.Units = units
+	}
+}
+
+// BetweenOptionLocation set location that calendar difference computed in,
+// defaults to location of start.
+func BetweenOptionLocation(loc *time.Location) BetweenOption {
+	return func(opts *BetweenOptions) {
+		opts.Location = loc
+	}
+}
+
+// civilDays returns days since unix epoch of date part of t.
+func civilDays(t time.Time) int64 {
+	var year, month, day = t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400
+}
+
+func absInt(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// Between returns calendar duration from start to end,
+// use largest unit first.
+// Result is negative if end is before start.
+//
+// When start is in the location used for calculation and seconds unit is used,
+// start plus result (see Duration.AddTo) equals to end.
+func Between(start, end time.Time, options ...BetweenOption) (ret Duration) {
+	var opts = &BetweenOptions{
+		Units: UnitAll &^ UnitWeek,
+	}
+	for _, i := range options {
+		i(opts)
+	}
+	var loc = opts.Location
+	if loc == nil {
+		loc = start.Location()
+	}
+	var t = start.In(loc)
+	end = end.In(loc)
+	ret.Negative = end.Before(t)
+
+	var sign = 1
+	if ret.Negative {
+		sign = -1
+	}
+	// reached reports whether v not passed end.
+	var reached = func(v time.Time) bool {
+		if ret.Negative {
+			return !v.Before(end)
+		}
+		return !v.After(end)
+	}
+
+	// Y
+	if opts.Units.Has(UnitYear) {
+		var n = absInt(int64(end.Year()-t.Year())) + 1
+		for n > 0 && !reached(t.AddDate(sign*int(n), 0, 0)) {
+			n--
+		}
+		t = t.AddDate(sign*int(n), 0, 0)
+		ret.Years = n
+	}
+
+	// M
+	if opts.Units.Has(UnitMonth) {
+		var n = absInt(int64(end.Year()-t.Year())*12+int64(end.Month()-t.Month())) + 1
+		for n > 0 && !reached(t.AddDate(0, sign*int(n), 0)) {
+			n--
+		}
+		t = t.AddDate(0, sign*int(n), 0)
+		ret.Months = n
+	}
+
+	// W, D
+	if opts.Units&(UnitWeek|UnitDay) != 0 {
+		var n = absInt(civilDays(end)-civilDays(t)) + 1
+		for n > 0 && !reached(t.AddDate(0, 0, sign*int(n))) {
+			n--
+		}
+		if opts.Units.Has(UnitWeek) {
+			ret.Weeks = n / 7
+			n -= ret.Weeks * 7
+			t = t.AddDate(0, 0, sign*7*int(ret.Weeks))
+		}
+		if opts.Units.Has(UnitDay) {
+			ret.Days = n
+			t = t.AddDate(0, 0, sign*int(n))
+		}
+	}
+
+	// T
+	var from, to = t, end
+	if ret.Negative {
+		from, to = to, from
+	}
+	var sec = to.Unix() - from.Unix()
+	var nsec = int64(to.Nanosecond() - from.Nanosecond())
+	if nsec < 0 {
+		sec--
+		nsec += int64(time.Second)
+	}
+	if opts.Units.Has(UnitHour) {
+		ret.Hours = sec / 3600
+		sec %= 3600
+	}
+	if opts.Units.Has(UnitMinute) {
+		ret.Minutes = sec / 60
+		sec %= 60
+	}
+	if opts.Units.Has(UnitSecond) {
+		ret.Seconds = sec
+		ret.Nanoseconds = nsec
+	}
+	return
+}
+
+// Since returns calendar duration from t to now, see Between.
+func Since(t time.Time, options ...BetweenOption) Duration {
+	return Between(t, time.Now(), options...)
+}
+
+// Until returns calendar duration from now to t, see Between.
+func Until(t time.Time, options ...BetweenOption) Duration {
+	return Between(time.Now(), t, options...)
+}
@@ -0,0 +1,409 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLoadLocation(t testing.TB, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func TestDurationAddTo(t *testing.T) {
+	var newYork = mustLoadLocation(t, "America/New_York")
+	for _, c := range []struct {
+		t        time.Time
+		duration Duration
+		expected time.Time
+		err      error
+	}{
+		{
+			t:        time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{},
+			expected: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 1},
+			expected: time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 1},
+			expected: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Years: 1},
+			expected: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Years: 4},
+			expected: time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Weeks: 1, Days: 1},
+			expected: time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Hours: 1, Minutes: 2, Seconds: 3, Nanoseconds: 4},
+			expected: time.Date(2024, 1, 1, 1, 2, 3, 4, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 3, 9, 12, 0, 0, 0, newYork),
+			duration: Duration{Days: 1},
+			expected: time.Date(2024, 3, 10, 12, 0, 0, 0, newYork),
+		},
+		{
+			t:        time.Date(2024, 3, 9, 12, 0, 0, 0, newYork),
+			duration: Duration{Hours: 24},
+			expected: time.Date(2024, 3, 10, 13, 0, 0, 0, newYork),
+		},
+		{
+			t:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 1, Days: 1, Negative: true},
+			expected: time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Months: -1, Days: 1},
+			expected: time.Date(2024, 2, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Hours: -1, Negative: true},
+			expected: time.Date(2024, 3, 15, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Years: minInt64, Negative: true},
+			err:      ErrOverflow,
+		},
+		{
+			t:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Weeks: maxInt64},
+			err:      ErrOverflow,
+		},
+		{
+			t:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Hours: maxInt64},
+			err:      ErrOverflow,
+		},
+		{
+			t:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Years: 1e17},
+			err:      ErrOverflow,
+		},
+		{
+			t:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 1e17},
+			err:      ErrOverflow,
+		},
+		{
+			t:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Days: 1e17},
+			err:      ErrOverflow,
+		},
+		{
+			t:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Days: -1e17},
+			err:      ErrOverflow,
+		},
+		{
+			t:        time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			duration: Duration{Years: 1e9},
+			expected: time.Date(1000002024, 3, 15, 0, 0, 0, 0, time.UTC),
+		},
+	} {
+		t.Run(c.duration.String(), func(t *testing.T) {
+			v, err := c.duration.AddTo(c.t)
+			require.Equal(t, c.err, err)
+			assert.True(t, c.expected.Equal(v), "expected %s, got %s", c.expected, v)
+			if err == nil {
+				assert.Equal(t, c.t.Location(), v.Location())
+			}
+		})
+	}
+}
+
+func TestDurationAddToMonthEnd(t *testing.T) {
+	for _, c := range []struct {
+		t        time.Time
+		duration Duration
+		policy   MonthEndPolicy
+		expected time.Time
+		err      error
+	}{
+		{
+			t:        time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 1},
+			policy:   MonthEndOverflow,
+			expected: time.Date(2024, 3, 2, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 1},
+			policy:   MonthEndClamp,
+			expected: time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2023, 1, 31, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 1},
+			policy:   MonthEndClamp,
+			expected: time.Date(2023, 2, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 1},
+			policy:   MonthEndReject,
+			err:      ErrMonthEnd,
+		},
+		{
+			t:        time.Date(2024, 1, 30, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 2},
+			policy:   MonthEndReject,
+			expected: time.Date(2024, 3, 30, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 3, 31, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 1, Negative: true},
+			policy:   MonthEndClamp,
+			expected: time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Months: -13},
+			policy:   MonthEndClamp,
+			expected: time.Date(2022, 12, 31, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Years: 1},
+			policy:   MonthEndClamp,
+			expected: time.Date(2025, 2, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Years: 1},
+			policy:   MonthEndReject,
+			err:      ErrMonthEnd,
+		},
+		{
+			// applied per component: year first, then month.
+			t:        time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Years: 1, Months: 1},
+			policy:   MonthEndClamp,
+			expected: time.Date(2025, 3, 28, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 1, Days: 1},
+			policy:   MonthEndClamp,
+			expected: time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			t:        time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Years: maxInt64},
+			policy:   MonthEndClamp,
+			err:      ErrOverflow,
+		},
+		{
+			t:        time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC),
+			duration: Duration{Months: 1e17},
+			policy:   MonthEndClamp,
+			err:      ErrOverflow,
+		},
+	} {
+		t.Run(c.duration.String(), func(t *testing.T) {
+			v, err := c.duration.AddTo(c.t, AddOptionMonthEnd(c.policy))
+			require.Equal(t, c.err, err)
+			assert.True(t, c.expected.Equal(v), "expected %s, got %s", c.expected, v)
+		})
+	}
+}
+
+func TestDurationMustAddTo(t *testing.T) {
+	assert.Equal(t,
+		time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		Duration{Months: 1}.MustAddTo(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+	)
+	assert.PanicsWithValue(t, ErrOverflow, func() {
+		Duration{Hours: maxInt64}.MustAddTo(time.Time{})
+	})
+}
+
+func TestBetween(t *testing.T) {
+	var newYork = mustLoadLocation(t, "America/New_York")
+	var shanghai = mustLoadLocation(t, "Asia/Shanghai")
+	for _, c := range []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		options  []BetweenOption
+		expected Duration
+	}{
+		{
+			name:     "zero",
+			start:    time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+			expected: Duration{},
+		},
+		{
+			name:     "all",
+			start:    time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2021, 3, 17, 4, 0, 0, 0, time.UTC),
+			expected: Duration{Years: 1, Months: 2, Days: 2, Hours: 4},
+		},
+		{
+			name:     "negative",
+			start:    time.Date(2021, 3, 17, 4, 0, 0, 0, time.UTC),
+			end:      time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+			expected: Duration{Years: 1, Months: 2, Days: 2, Hours: 4, Negative: true},
+		},
+		{
+			name:     "fraction",
+			start:    time.Date(2020, 1, 15, 0, 0, 0, 5e8, time.UTC),
+			end:      time.Date(2020, 1, 15, 0, 1, 1, 0, time.UTC),
+			expected: Duration{Minutes: 1, Nanoseconds: 5e8},
+		},
+		{
+			name:     "borrow",
+			start:    time.Date(2020, 1, 15, 23, 0, 0, 0, time.UTC),
+			end:      time.Date(2020, 3, 15, 1, 0, 0, 0, time.UTC),
+			expected: Duration{Months: 1, Days: 28, Hours: 2},
+		},
+		{
+			name:     "month end",
+			start:    time.Date(2021, 1, 31, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC),
+			expected: Duration{Days: 28},
+		},
+		{
+			name:     "weeks",
+			start:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2020, 2, 18, 0, 0, 0, 0, time.UTC),
+			options:  []BetweenOption{BetweenOptionUnits(UnitAll)},
+			expected: Duration{Months: 1, Weeks: 2, Days: 3},
+		},
+		{
+			name:     "weeks only",
+			start:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2020, 1, 18, 1, 0, 0, 0, time.UTC),
+			options:  []BetweenOption{BetweenOptionUnits(UnitWeek | UnitHour)},
+			expected: Duration{Weeks: 2, Hours: 73},
+		},
+		{
+			name:     "days only",
+			start:    time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2021, 3, 17, 4, 0, 0, 0, time.UTC),
+			options:  []BetweenOption{BetweenOptionUnits(UnitDay)},
+			expected: Duration{Days: 427},
+		},
+		{
+			name:     "hours max",
+			start:    time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2020, 1, 17, 4, 5, 6, 7, time.UTC),
+			options:  []BetweenOption{BetweenOptionUnits(UnitHour | UnitMinute | UnitSecond)},
+			expected: Duration{Hours: 52, Minutes: 5, Seconds: 6, Nanoseconds: 7},
+		},
+		{
+			name:     "seconds only",
+			start:    time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2020, 1, 15, 1, 1, 1, 0, time.UTC),
+			options:  []BetweenOption{BetweenOptionUnits(UnitSecond)},
+			expected: Duration{Seconds: 3661},
+		},
+		{
+			name:     "dst",
+			start:    time.Date(2024, 3, 9, 12, 0, 0, 0, newYork),
+			end:      time.Date(2024, 3, 10, 12, 0, 0, 0, newYork),
+			expected: Duration{Days: 1},
+		},
+		{
+			name:     "dst hours",
+			start:    time.Date(2024, 3, 9, 12, 0, 0, 0, newYork),
+			end:      time.Date(2024, 3, 10, 12, 0, 0, 0, newYork),
+			options:  []BetweenOption{BetweenOptionUnits(UnitHour)},
+			expected: Duration{Hours: 23},
+		},
+		{
+			name:     "location",
+			start:    time.Date(2020, 1, 31, 20, 0, 0, 0, time.UTC),
+			end:      time.Date(2020, 2, 29, 20, 0, 0, 0, time.UTC),
+			options:  []BetweenOption{BetweenOptionLocation(shanghai)},
+			expected: Duration{Months: 1},
+		},
+		{
+			name:     "without location",
+			start:    time.Date(2020, 1, 31, 20, 0, 0, 0, time.UTC),
+			end:      time.Date(2020, 2, 29, 20, 0, 0, 0, time.UTC),
+			expected: Duration{Days: 29},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			v := Between(c.start, c.end, c.options...)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+}
+
+func TestBetweenRoundTrip(t *testing.T) {
+	var newYork = mustLoadLocation(t, "America/New_York")
+	var times = []time.Time{
+		time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 2, 29, 12, 30, 0, 0, time.UTC),
+		time.Date(2021, 2, 28, 23, 59, 59, 999999999, time.UTC),
+		time.Date(2021, 3, 31, 1, 0, 0, 0, time.UTC),
+		time.Date(1999, 12, 31, 23, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 10, 1, 30, 0, 0, newYork),
+		time.Date(2024, 3, 10, 3, 30, 0, 0, newYork),
+		time.Date(2024, 11, 3, 1, 30, 0, 0, newYork),
+		time.Date(2024, 10, 31, 1, 30, 0, 0, newYork),
+	}
+	for _, units := range []Unit{
+		UnitAll,
+		UnitAll &^ UnitWeek,
+		UnitMonth | UnitDay | UnitSecond,
+		UnitWeek | UnitSecond,
+		UnitSecond,
+	} {
+		for _, a := range times {
+			for _, b := range times {
+				d := Between(a, b, BetweenOptionUnits(units))
+				v, err := d.AddTo(a)
+				require.NoError(t, err)
+				assert.True(t, b.Equal(v), "%s + %s: expected %s, got %s", a, d, b, v)
+			}
+		}
+	}
+}
+
+func TestSinceUntil(t *testing.T) {
+	var now = time.Now()
+	assert.Equal(t, Duration{Days: 1}, Since(now.AddDate(0, 0, -1), BetweenOptionUnits(UnitDay)))
+	assert.Equal(t, Duration{Days: 1}, Until(now.AddDate(0, 0, 2), BetweenOptionUnits(UnitDay)))
+}
+
+func BenchmarkDurationAddTo(b *testing.B) {
+	x := Duration{Years: 1, Months: 2, Weeks: 3, Days: 4, Hours: 5, Minutes: 6, Seconds: 7}
+	t := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		_ = x.MustAddTo(t)
+	}
+}
+
+func BenchmarkBetween(b *testing.B) {
+	start := time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 3, 17, 4, 0, 0, 0, time.UTC)
+	for i := 0; i < b.N; i++ {
+		_ = Between(start, end)
+	}
+}
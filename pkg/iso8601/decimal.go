@@ -0,0 +1,290 @@
+package iso8601
+
+import (
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// DecimalComponent is a duration component that keeps decimal value as written.
+type DecimalComponent struct {
+	// Unit of component, only contains one unit.
+	Unit Unit
+	// Mantissa and Scale represent value Mantissa * 10^-Scale,
+	// e.g. 1.50 is Mantissa 150 and Scale 2.
+	Mantissa int64
+	Scale    int
+}
+
+// DecimalDuration is a duration that keeps components as written,
+// fraction stays on the component it was written on instead of spread into smaller components.
+type DecimalDuration struct {
+	// Components in written order.
+	Components []DecimalComponent
+	Negative   bool
+	// Comma is true when ',' is used as decimal sign.
+	Comma bool
+}
+
+// unitIndex returns index of u in nominalSizes.
+func unitIndex(u Unit) int {
+	return bits.TrailingZeros8(uint8(u))
+}
+
+// unitDesignator returns designator of single unit u,
+// and whether it is in time section.
+func unitDesignator(u Unit) (designator byte, time bool) {
+	switch u {
+	case UnitYear:
+		return 'Y', false
+	case UnitMonth:
+		return 'M', false
+	case UnitWeek:
+		return 'W', false
+	case UnitDay:
+		return 'D', false
+	case UnitHour:
+		return 'H', true
+	case UnitMinute:
+		return 'M', true
+	case UnitSecond:
+		return 'S', true
+	}
+	return '?', false
+}
+
+// parseMantissa parse concatenated digits as int64.
+func parseMantissa(digits ...string) (v int64, err error) {
+	for _, s := range digits {
+		for i := 0; i < len(s); i++ {
+			var d = int64(s[i] - '0')
+			if v > (maxInt64-d)/10 {
+				return 0, ErrOverflow
+			}
+			v = v*10 + d
+		}
+	}
+	return
+}
+
+// ParseDecimalDuration parse iso8601 duration string into DecimalDuration,
+// accepts same input as ParseDuration with same options.
+//
+// Text that conforms to ISO 8601 (see ParseDurationOptionStrict)
+// formats back to the same text.
+// Alternative format is converted to designator format,
+// and mantissa must fit in int64.
+//
+// Returned error is ErrInvalidDuration.
+func ParseDecimalDuration(s string, options ...ParseDurationOption) (ret DecimalDuration, err error) {
+	d, err := ParseDuration(s, options...)
+	if err != nil {
+		return
+	}
+	orig := s
+	ret.Negative, s = leadingNegative(s)
+	s = s[1:]
+	if isAlternativeDuration(s) {
+		ret.Negative = d.Negative
+		ret.Components = alternativeDecimalComponents(d)
+		return
+	}
+
+	var afterT bool
+	for s != "" {
+		if s[0] == 'T' {
+			s = s[1:]
+			afterT = true
+			continue
+		}
+		var start = len(orig) - len(s)
+		var neg bool
+		neg, s = leadingNegative(s)
+		var integer = s[:leadingDigits(s)]
+		s = s[len(integer):]
+		var fraction string
+		if s[0] == '.' || s[0] == ',' {
+			ret.Comma = s[0] == ','
+			s = s[1:]
+			fraction = s[:leadingDigits(s)]
+			s = s[len(fraction):]
+		}
+		var c = DecimalComponent{Scale: len(fraction)}
+		switch {
+		case s[0] == 'Y':
+			c.Unit = UnitYear
+		case s[0] == 'M' && !afterT:
+			c.Unit = UnitMonth
+		case s[0] == 'W':
+			c.Unit = UnitWeek
+		case s[0] == 'D':
+			c.Unit = UnitDay
+		case s[0] == 'H':
+			c.Unit = UnitHour
+		case s[0] == 'M':
+			c.Unit = UnitMinute
+		case s[0] == 'S':
+			c.Unit = UnitSecond
+		}
+		s = s[1:]
+		c.Mantissa, err = parseMantissa(integer, fraction)
+		if err != nil {
+			err = newErrInvalidDuration(orig, start, len(orig)-len(s)-start, ReasonOverflow)
+			return DecimalDuration{}, err
+		}
+		if neg {
+			c.Mantissa = -c.Mantissa
+		}
+		ret.Components = append(ret.Components, c)
+	}
+	return
+}
+
+// alternativeDecimalComponents returns components of d parsed from alternative format.
+func alternativeDecimalComponents(d Duration) []DecimalComponent {
+	var ret = []DecimalComponent{
+		{Unit: UnitYear, Mantissa: d.Years},
+		{Unit: UnitMonth, Mantissa: d.Months},
+		{Unit: UnitDay, Mantissa: d.Days},
+		{Unit: UnitHour, Mantissa: d.Hours},
+		{Unit: UnitMinute, Mantissa: d.Minutes},
+		{Unit: UnitSecond, Mantissa: d.Seconds},
+	}
+	if d.Nanoseconds != 0 {
+		var c = &ret[len(ret)-1]
+		c.Mantissa = c.Mantissa*1e9 + d.Nanoseconds
+		c.Scale = 9
+		for c.Mantissa%10 == 0 {
+			c.Mantissa /= 10
+			c.Scale--
+		}
+	}
+	return ret
+}
+
+// MustParseDecimalDuration is like ParseDecimalDuration but panics on error.
+func MustParseDecimalDuration(s string, options ...ParseDurationOption) DecimalDuration {
+	var ret, err = ParseDecimalDuration(s, options...)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// AppendFormat append formatted d to b,
+// components are written in order with their scale.
+func (d DecimalDuration) AppendFormat(b []byte) []byte {
+	if d.Negative {
+		b = append(b, '-')
+	}
+	b = append(b, 'P')
+	if len(d.Components) == 0 {
+		return append(b, '0', 'D')
+	}
+	var sep byte = '.'
+	if d.Comma {
+		sep = ','
+	}
+	var afterT bool
+	for _, c := range d.Components {
+		var designator, isTime = unitDesignator(c.Unit)
+		if isTime && !afterT {
+			b = append(b, 'T')
+			afterT = true
+		}
+		var v = uint64(c.Mantissa)
+		if c.Mantissa < 0 {
+			b = append(b, '-')
+			v = -v
+		}
+		var buf [20]byte
+		b = appendDecimalDigits(b, strconv.AppendUint(buf[:0], v, 10), c.Scale, sep)
+		b = append(b, designator)
+	}
+	return b
+}
+
+// appendDecimalDigits append digits with decimal sign before last scale digits,
+// digits is padded with zero when it is shorter than scale+1.
+func appendDecimalDigits(b []byte, digits []byte, scale int, sep byte) []byte {
+	if scale <= 0 {
+		return append(b, digits...)
+	}
+	var n = len(digits) - scale
+	if n <= 0 {
+		b = append(b, '0', sep)
+		for ; n < 0; n++ {
+			b = append(b, '0')
+		}
+		return append(b, digits...)
+	}
+	b = append(b, digits[:n]...)
+	b = append(b, sep)
+	return append(b, digits[n:]...)
+}
+
+func (d DecimalDuration) String() string {
+	return string(d.AppendFormat(make([]byte, 0, 64)))
+}
+
+// split returns integer part and fraction digits of c,
+// both are non-negative.
+func (c DecimalComponent) split() (integer int64, fraction string) {
+	var v = uint64(c.Mantissa)
+	if c.Mantissa < 0 {
+		v = -v
+	}
+	var digits = strconv.FormatUint(v, 10)
+	if c.Scale <= 0 {
+		integer, _ = parseMantissa(digits)
+		return
+	}
+	var n = len(digits) - c.Scale
+	if n <= 0 {
+		return 0, strings.Repeat("0", -n) + digits
+	}
+	integer, _ = parseMantissa(digits[:n])
+	return integer, digits[n:]
+}
+
+// Duration converts d to Duration like ParseDuration,
+// fraction is spread into smaller components.
+func (d DecimalDuration) Duration() (ret Duration, err error) {
+	ret.Negative = d.Negative
+	var fields = [...]*int64{&ret.Years, &ret.Months, &ret.Weeks, &ret.Days, &ret.Hours, &ret.Minutes, &ret.Seconds}
+	for _, c := range d.Components {
+		var index = unitIndex(c.Unit)
+		var integer, fraction = c.split()
+		var nano = fractionOf(fraction, nominalSizes[index])
+		if c.Mantissa < 0 {
+			integer, nano = -integer, -nano
+		}
+		*fields[index], err = addInt(*fields[index], integer)
+		if err == nil {
+			err = spreadNano(&ret, index, nano)
+		}
+		if err != nil {
+			return Duration{}, err
+		}
+	}
+	err = carryNano(&ret)
+	if err != nil {
+		return Duration{}, err
+	}
+	return
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d DecimalDuration) MarshalText() ([]byte, error) {
+	return d.AppendFormat(make([]byte, 0, 32)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *DecimalDuration) UnmarshalText(data []byte) error {
+	var v, err = ParseDecimalDuration(string(data))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
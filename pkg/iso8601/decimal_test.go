@@ -0,0 +1,156 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimalDuration(t *testing.T) {
+	for _, c := range []struct {
+		s        string
+		expected DecimalDuration
+		str      string
+		duration Duration
+		err      error
+	}{
+		{
+			s:        "P0.5Y",
+			expected: DecimalDuration{Components: []DecimalComponent{{Unit: UnitYear, Mantissa: 5, Scale: 1}}},
+			duration: Duration{Months: 6},
+		},
+		{
+			s: "P1Y2M3DT4H5M6.50S",
+			expected: DecimalDuration{Components: []DecimalComponent{
+				{Unit: UnitYear, Mantissa: 1},
+				{Unit: UnitMonth, Mantissa: 2},
+				{Unit: UnitDay, Mantissa: 3},
+				{Unit: UnitHour, Mantissa: 4},
+				{Unit: UnitMinute, Mantissa: 5},
+				{Unit: UnitSecond, Mantissa: 650, Scale: 2},
+			}},
+			duration: Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 5e8},
+		},
+		{
+			s:        "-P0Y0,005W",
+			expected: DecimalDuration{Components: []DecimalComponent{{Unit: UnitYear}, {Unit: UnitWeek, Mantissa: 5, Scale: 3}}, Negative: true, Comma: true},
+			duration: Duration{Minutes: 50, Seconds: 24, Negative: true},
+		},
+		{
+			s:        "PT-1.25M",
+			expected: DecimalDuration{Components: []DecimalComponent{{Unit: UnitMinute, Mantissa: -125, Scale: 2}}},
+			duration: Duration{Minutes: -1, Seconds: -15},
+		},
+		{
+			s:        "P0.5M",
+			expected: DecimalDuration{Components: []DecimalComponent{{Unit: UnitMonth, Mantissa: 5, Scale: 1}}},
+			duration: Duration{Weeks: 2, Days: 1, Hours: 5, Minutes: 14, Seconds: 33},
+		},
+		{
+			s:        "P.5D",
+			expected: DecimalDuration{Components: []DecimalComponent{{Unit: UnitDay, Mantissa: 5, Scale: 1}}},
+			str:      "P0.5D",
+			duration: Duration{Hours: 12},
+		},
+		{
+			s:        "P",
+			expected: DecimalDuration{},
+			str:      "P0D",
+		},
+		{
+			s: "P0003-06-04T12:30:05.5",
+			expected: DecimalDuration{Components: []DecimalComponent{
+				{Unit: UnitYear, Mantissa: 3},
+				{Unit: UnitMonth, Mantissa: 6},
+				{Unit: UnitDay, Mantissa: 4},
+				{Unit: UnitHour, Mantissa: 12},
+				{Unit: UnitMinute, Mantissa: 30},
+				{Unit: UnitSecond, Mantissa: 55, Scale: 1},
+			}},
+			str:      "P3Y6M4DT12H30M5.5S",
+			duration: Duration{Years: 3, Months: 6, Days: 4, Hours: 12, Minutes: 30, Seconds: 5, Nanoseconds: 5e8},
+		},
+		{
+			s:   "P1X",
+			err: ErrInvalidDuration{String: "P1X", Offset: 2, Token: "X", Reason: ReasonUnknownDesignator},
+		},
+		{
+			s:   "PT1.0000000000000000001S",
+			err: ErrInvalidDuration{String: "PT1.0000000000000000001S", Offset: 2, Token: "1.0000000000000000001S", Reason: ReasonOverflow},
+		},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseDecimalDuration(c.s)
+			require.Equal(t, c.err, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, c.expected, v)
+			var str = c.str
+			if str == "" {
+				str = c.s
+			}
+			assert.Equal(t, str, v.String())
+			d, err := v.Duration()
+			require.NoError(t, err)
+			assert.Equal(t, c.duration, d)
+			expected, err := ParseDuration(c.s)
+			require.NoError(t, err)
+			assert.Equal(t, expected, d)
+		})
+	}
+}
+
+func TestParseDecimalDurationStrict(t *testing.T) {
+	_, err := ParseDecimalDuration("P1D1D", ParseDurationOptionStrict())
+	assert.Equal(t, ErrInvalidDuration{String: "P1D1D", Offset: 4, Token: "D", Reason: ReasonRepeatedDesignator}, err)
+	assert.Panics(t, func() {
+		MustParseDecimalDuration("P1D1D", ParseDurationOptionStrict())
+	})
+	assert.Equal(t, "P1D1D", MustParseDecimalDuration("P1D1D").String())
+}
+
+func TestDecimalDurationDuration(t *testing.T) {
+	for _, c := range []struct {
+		value    DecimalDuration
+		expected Duration
+		err      error
+	}{
+		{
+			value:    DecimalDuration{Components: []DecimalComponent{{Unit: UnitSecond, Mantissa: 15, Scale: 1}, {Unit: UnitSecond, Mantissa: 5, Scale: 1}}},
+			expected: Duration{Seconds: 2},
+		},
+		{
+			value:    DecimalDuration{Components: []DecimalComponent{{Unit: UnitHour, Mantissa: 1, Scale: 20}}},
+			expected: Duration{},
+		},
+		{
+			value: DecimalDuration{Components: []DecimalComponent{{Unit: UnitDay, Mantissa: maxInt64}, {Unit: UnitDay, Mantissa: 1}}},
+			err:   ErrOverflow,
+		},
+	} {
+		t.Run(c.value.String(), func(t *testing.T) {
+			v, err := c.value.Duration()
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+}
+
+func TestDecimalDurationJSON(t *testing.T) {
+	type payload struct {
+		D DecimalDuration `json:"d"`
+	}
+	var v payload
+	err := json.Unmarshal([]byte(`{"d":"PT0,250S"}`), &v)
+	require.NoError(t, err)
+	assert.Equal(t, DecimalDuration{Components: []DecimalComponent{{Unit: UnitSecond, Mantissa: 250, Scale: 3}}, Comma: true}, v.D)
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"d":"PT0,250S"}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"d":"P1X"}`), &v)
+	assert.IsType(t, ErrInvalidDuration{}, err)
+}
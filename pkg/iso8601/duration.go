@@ -2,6 +2,7 @@ package iso8601
 
 import (
 	"errors"
+	"math/bits"
 	"strconv"
 	"time"
 )
@@ -38,19 +39,46 @@ type Duration struct {
 	Negative    bool
 }
 
+// Unit of duration component,
+// can be combined with bitwise or to represent a set of units.
+type Unit uint8
+
+const (
+	// UnitYear for Duration.Years
+	UnitYear Unit = 1 << iota
+	// UnitMonth for Duration.Months
+	UnitMonth
+	// UnitWeek for Duration.Weeks
+	UnitWeek
+	// UnitDay for Duration.Days
+	UnitDay
+	// UnitHour for Duration.Hours
+	UnitHour
+	// UnitMinute for Duration.Minutes
+	UnitMinute
+	// UnitSecond for Duration.Seconds and Duration.Nanoseconds
+	UnitSecond
+
+	// UnitAll contains all units.
+	UnitAll = UnitYear | UnitMonth | UnitWeek | UnitDay | UnitHour | UnitMinute | UnitSecond
+)
+
+// Has reports whether u contains every unit in v.
+func (u Unit) Has(v Unit) bool {
+	return u&v == v
+}
+
 // ErrOverflow indicate value is overflowed.
 var ErrOverflow = errors.New("iso8601: overflow")
 
 // addInt handle overflow when add int64
 func addInt(base int64, v int64) (int64, error) {
 	if base > 0 {
-		if v > maxInt64-base ||
-			v < minInt64+base {
+		if v > maxInt64-base {
 			return 0, ErrOverflow
 		}
 	} else {
-		if v > maxInt64+base ||
-			(v < minInt64-base) {
+		if v < minInt64-base {
 			return 0, ErrOverflow
 		}
 	}
@@ -59,12 +87,17 @@ func addInt(base int64, v int64) (int64, error) {
 
 // multiplyInt handle overflow when multiple int64
 func multiplyInt(base int64, v int64) (int64, error) {
-	if base > 0 {
+	switch {
+	case base == 0:
+		return 0, nil
+	case base == -1:
+		return negateInt(v)
+	case base > 0:
 		if v > maxInt64/base ||
 			v < minInt64/base {
 			return 0, ErrOverflow
 		}
-	} else {
+	default:
 		if v > minInt64/base ||
 			v < maxInt64/base {
 			return 0, ErrOverflow
@@ -72,6 +105,15 @@ func multiplyInt(base int64, v int64) (int64, error) {
 	}
 	return base * v, nil
 }
+
+// negateInt handle overflow when negate int64
+func negateInt(v int64) (int64, error) {
+	if v == minInt64 {
+		return 0, ErrOverflow
+	}
+	return -v, nil
+}
+
 func addNano(base int64, num int64, unit time.Duration) (int64, error) {
 	var v int64
 	var err error
@@ -133,112 +175,73 @@ func (d Duration) MustTimeDuration() time.Duration {
 	return ret
 }
 
-// appendFrac append the fraction of v/10**prec (e.g., ".12345") into the
-// tail of buf, omitting trailing zeros. It omits the decimal
-// point too when the fraction is 0. It returns the index where the
-// output bytes begin and the value v/10**prec.
-func appendFrac(b []byte, v uint64, prec int) []byte {
-	var buf [10]byte
-	var w = len(buf)
-	var print bool
-	for i := 0; i < prec; i++ {
-		digit := v % 10
-		print = print || digit != 0
-		if print {
-			w--
-			buf[w] = byte(digit) + '0'
-		}
-		v /= 10
+// AppendFormat is like String but appends the textual
+// representation to b and returns the extended buffer.
+func (d Duration) AppendFormat(b []byte, options ...FormatOption) []byte {
+	var opts = defaultFormatOptions
+	if len(options) > 0 {
+		opts = newFormatOptions(options)
 	}
-	if print {
-		w--
-		buf[w] = '.'
+	if opts.Alternative && d.fitsAlternative() {
+		return d.appendAlternative(b, opts)
 	}
-	return append(b, buf[w:]...)
-}
 
-// AppendFormat is like String but appends the textual
-// representation to b and returns the extended buffer.
-func (d Duration) AppendFormat(b []byte) []byte {
 	if d.Negative {
 		b = append(b, '-')
 	}
 
 	b = append(b, 'P')
 	var prefixWidth = len(b)
-
-	// Y
-	if d.Years != 0 {
-		b = strconv.AppendInt(b, d.Years, 10)
-		b = append(b, 'Y')
-	}
-
-	// M
-	if d.Months != 0 {
-		b = strconv.AppendInt(b, d.Months, 10)
-		b = append(b, 'M')
-	}
-
-	// W
-	if d.Weeks != 0 {
-		b = strconv.AppendInt(b, d.Weeks, 10)
-		b = append(b, 'W')
-	}
-
-	// D
-	if d.Days != 0 {
-		b = strconv.AppendInt(b, d.Days, 10)
-		b = append(b, 'D')
-	}
-
-	// T
-	if d.Hours != 0 ||
-		d.Minutes != 0 ||
-		d.Seconds != 0 ||
-		d.Nanoseconds != 0 {
-		b = append(b, 'T')
-	}
-
-	// H
-	if d.Hours != 0 {
-		b = strconv.AppendInt(b, d.Hours, 10)
-		b = append(b, 'H')
-	}
-
-	// M
-	if d.Minutes != 0 {
-		b = strconv.AppendInt(b, d.Minutes, 10)
-		b = append(b, 'M')
-	}
-
-	// S
-	if d.Seconds != 0 ||
-		d.Nanoseconds != 0 {
-		var v, f = uint64(d.Seconds), uint64(d.Nanoseconds)
-		var neg bool
-		if d.Seconds < 0 {
-			neg = true
-			v = -v
+	var l = newDurationLayout(d, opts)
+	var afterT bool
+	for i, v := range l.values {
+		var nano int64
+		if i == l.last {
+			nano = l.nano
 		}
-		if d.Nanoseconds < 0 {
-			if neg {
-				f = -f
-			} else {
-				v--
-				f = uint64(int64(time.Second) + d.Nanoseconds)
-			}
+		if v == 0 && nano == 0 {
+			continue
 		}
-		if neg {
+		var size = int64(nominalSizes[i])
+		if v > 0 && nano < 0 {
+			v--
+			nano += size
+		} else if v < 0 && nano > 0 {
+			v++
+			nano -= size
+		}
+		var start = len(b)
+		if i >= 4 && !afterT {
+			b = append(b, 'T')
+			afterT = true
+		}
+		var u = uint64(v)
+		if v < 0 || v == 0 && nano < 0 {
 			b = append(b, '-')
+			u = -u
 		}
-		b = strconv.AppendUint(b, v, 10)
-		b = appendFrac(b, f, 9)
-		b = append(b, 'S')
+		b = strconv.AppendUint(b, u, 10)
+		if i == l.last {
+			var digitsStart = len(b)
+			b = opts.appendFraction(b, uint64(absInt(nano)), uint64(size))
+			if v == 0 && len(b) == digitsStart {
+				// fraction truncated to nothing
+				b = b[:start]
+				continue
+			}
+		}
+		var designator, _ = unitDesignator(Unit(1 << uint(i)))
+		b = append(b, designator)
 	}
 
 	if len(b) == prefixWidth {
+		var i = opts.zeroUnit()
+		if i >= 4 {
+			b = append(b, 'T')
+		}
 		b = append(b, '0')
-		b = append(b, 'D')
+		var designator, _ = unitDesignator(Unit(1 << uint(i)))
+		b = append(b, designator)
 	}
 	return b
 }
@@ -301,74 +304,223 @@ func leadingInt(s string) (x int64, rem string, err error) {
 	return x, s[i:], nil
 }
 
-// leadingFraction consumes the leading [0-9]* from s.
-// It is used only for fractions, so does not return an error on overflow,
-// it just stops accumulating precision.
-func leadingFraction(s string) (x int64, scale float64, rem string) {
-	i := 0
-	scale = 1
-	overflow := false
-	for ; i < len(s); i++ {
-		c := s[i]
-		if c < '0' || c > '9' {
-			break
-		}
-		if overflow {
-			continue
-		}
-		if x > (1<<63-1)/10 {
-			// It's possible for overflow to give a positive number, so take care.
-			overflow = true
-			continue
+// nominalSizes of duration components ordered from largest to smallest,
+// used to spread fraction into smaller components.
+var nominalSizes = [...]time.Duration{Year, Month, Week, Day, time.Hour, time.Minute, time.Second, time.Nanosecond}
+
+const (
+	fractionChunkDigits        = 18
+	fractionChunkScale  uint64 = 1e18
+)
+
+// fractionOf returns nanoseconds of `0.<digits>` times unit,
+// exact for any count of digits and truncated toward zero.
+// digits must only contains [0-9].
+func fractionOf(digits string, unit time.Duration) int64 {
+	// process 18 digits chunks from last to first:
+	// acc = floor((chunk * unit + acc) / 1e18),
+	// floor of each step does not change floor of final result.
+	var acc uint64
+	for end := len(digits); end > 0; {
+		var start = (end - 1) / fractionChunkDigits * fractionChunkDigits
+		var chunk uint64
+		for i := start; i < start+fractionChunkDigits; i++ {
+			chunk *= 10
+			if i < end {
+				chunk += uint64(digits[i] - '0')
+			}
 		}
-		y := x*10 + int64(c) - '0'
-		if y < 0 {
-			overflow = true
-			continue
+		hi, lo := bits.Mul64(chunk, uint64(unit))
+		var carry uint64
+		lo, carry = bits.Add64(lo, acc, 0)
+		acc, _ = bits.Div64(hi+carry, lo, fractionChunkScale)
+		end = start
+	}
+	return int64(acc)
+}
+
+// spreadNano spreads nano into components smaller than nominalSizes[from],
+// larger component first.
+func spreadNano(d *Duration, from int, nano int64) (err error) {
+	var fields = [...]*int64{&d.Years, &d.Months, &d.Weeks, &d.Days, &d.Hours, &d.Minutes, &d.Seconds, &d.Nanoseconds}
+	for i := from + 1; i < len(fields); i++ {
+		var size = int64(nominalSizes[i])
+		*fields[i], err = addInt(*fields[i], nano/size)
+		if err != nil {
+			return
 		}
-		x = y
-		scale *= 10
+		nano %= size
 	}
-	return x, scale, s[i:]
+	return
 }
 
 // ErrInvalidDuration returned when parse failed.
 type ErrInvalidDuration struct {
 	String string
+	// Offset is byte offset of Token in String.
+	Offset int
+	// Token is the offending part of String, may be empty (e.g. when string ended).
+	Token  string
+	Reason Reason
+}
+
+func newErrInvalidDuration(s string, offset, length int, reason Reason) ErrInvalidDuration {
+	return ErrInvalidDuration{
+		String: s,
+		Offset: offset,
+		Token:  s[offset : offset+length],
+		Reason: reason,
+	}
+}
+
+// parseErrorMessage returns message for parse error of kind (e.g. "duration").
+func parseErrorMessage(kind, s string, offset int, token string, reason Reason) string {
+	if reason == ReasonUnknown {
+		return "iso8601: invalid " + kind + " " + s
+	}
+	var ret = "iso8601: invalid " + kind + " " + s + ": " + reason.String()
+	if token != "" {
+		ret += " " + strconv.Quote(token)
+	}
+	return ret + " at offset " + strconv.Itoa(offset)
 }
 
 func (err ErrInvalidDuration) Error() string {
-	return "iso8601: invalid duration " + err.String
+	return parseErrorMessage("duration", err.String, err.Offset, err.Token, err.Reason)
+}
+
+// Unwrap returns ErrOverflow for ReasonOverflow,
+// so errors.Is(err, ErrOverflow) works.
+func (err ErrInvalidDuration) Unwrap() error {
+	if err.Reason == ReasonOverflow {
+		return ErrOverflow
+	}
+	return nil
+}
+
+// ParseDurationOptions for ParseDuration.
+type ParseDurationOptions struct {
+	Strict bool
+}
+
+// ParseDurationOption mutate ParseDurationOptions.
+type ParseDurationOption func(opts *ParseDurationOptions)
+
+// ParseDurationOptionStrict only accept duration that conforms to ISO 8601-1:
+// designators must in order and occur at most once,
+// time section must not be empty,
+// sign only allowed as leading '-',
+// number must have digits on both side of decimal sign
+// and weeks can not combine with other components.
+func ParseDurationOptionStrict() ParseDurationOption {
+	return func(opts *ParseDurationOptions) {
+		opts.Strict = true
+	}
+}
+
+func newParseDurationOptions(options []ParseDurationOption) ParseDurationOptions {
+	var opts = new(ParseDurationOptions)
+	for _, i := range options {
+		i(opts)
+	}
+	return *opts
+}
+
+// componentLength returns length of leading `[+-]?[0-9]*([.,][0-9]*)?.?` in s.
+func componentLength(s string) int {
+	var i int
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+		i++
+	}
+	i += leadingDigits(s[i:])
+	if i < len(s) && (s[i] == '.' || s[i] == ',') {
+		i++
+		i += leadingDigits(s[i:])
+	}
+	if i < len(s) {
+		i++
+	}
+	return i
 }
 
 // ParseDuration parse iso8601 duration string.
-func ParseDuration(s string) (ret Duration, err error) {
+//
+// Both '.' and ',' are accepted as decimal sign.
+// Fraction is converted exactly with nominal units (see Year, Month, Week and Day),
+// and spread into smaller components, truncated toward zero at nanosecond,
+// e.g. P0.5M is P2W1DT5H14M33S.
+// Alternative format is also supported,
+// extended (e.g. P0003-06-04T12:30:05) or basic (e.g. P00030604T123005).
+//
+// It is lenient by default: allows sign on each component, leading '+',
+// repeated designators (values are summed), designators out of order, empty time section
+// and weeks combined with other components.
+// Use ParseDurationOptionStrict to reject them.
+//
+// Returned error is ErrInvalidDuration.
+func ParseDuration(s string, options ...ParseDurationOption) (ret Duration, err error) {
+	var opts ParseDurationOptions
+	if len(options) > 0 {
+		opts = newParseDurationOptions(options)
+	}
 	orig := s
+	if opts.Strict && s != "" && s[0] == '+' {
+		err = newErrInvalidDuration(orig, 0, 1, ReasonSign)
+		return
+	}
 	ret.Negative, s = leadingNegative(s)
 
 	if s == "" || s[0] != 'P' {
-		err = ErrInvalidDuration{String: orig}
+		err = newErrInvalidDuration(orig, len(orig)-len(s), 0, ReasonMissingDesignator)
 		return
 	}
 	s = s[1:]
+	if opts.Strict && s == "" {
+		err = newErrInvalidDuration(orig, len(orig), 0, ReasonNoComponent)
+		return
+	}
+	if isAlternativeDuration(s) {
+		var offset, length, reason = parseAlternativeDuration(s, &ret)
+		if reason != ReasonUnknown {
+			err = newErrInvalidDuration(orig, len(orig)-len(s)+offset, length, reason)
+		}
+		return
+	}
 
 	var afterT bool
+	// order of last designator, used by strict mode
+	var lastOrder int
+	var hasWeeks bool
 	for s != "" {
+		var start = len(orig) - len(s)
 		if s[0] == 'T' {
+			if opts.Strict && afterT {
+				err = newErrInvalidDuration(orig, start, 1, ReasonRepeatedDesignator)
+				return
+			}
 			s = s[1:]
 			afterT = true
+			if opts.Strict && s == "" {
+				err = newErrInvalidDuration(orig, start, 1, ReasonEmptyTimeSection)
+				return
+			}
 			continue
 		}
-		var v, f int64
-		var scale float64 = 1
+		var v int64
+		var digits string
 		var neg bool
-		var pre, post bool
+		var pre, dot bool
+		if opts.Strict && (s[0] == '-' || s[0] == '+') {
+			err = newErrInvalidDuration(orig, start, 1, ReasonSign)
+			return
+		}
 		neg, s = leadingNegative(s)
 
 		// Consume [0-9]*
 		pl := len(s)
 		v, s, err = leadingInt(s)
 		if err != nil {
+			err = newErrInvalidDuration(orig, start, componentLength(orig[start:]), ReasonOverflow)
 			return
 		}
 		pre = pl != len(s) // whether we consumed anything before a period
@@ -376,69 +528,99 @@ func ParseDuration(s string) (ret Duration, err error) {
 			v = -v
 		}
 
-		// Consume (\.[0-9]*)?
-		if s != "" && s[0] == '.' {
+		// Consume ([.,][0-9]*)?
+		if s != "" && (s[0] == '.' || s[0] == ',') {
 			s = s[1:]
-			pl := len(s)
-			f, scale, s = leadingFraction(s)
-			post = pl != len(s)
-			if neg {
-				f = -f
-			}
+			dot = true
+			digits = s[:leadingDigits(s)]
+			s = s[len(digits):]
 		}
-		if !pre && !post {
+		var post = digits != ""
+		if !pre && !post ||
+			opts.Strict && (!pre || dot && !post) {
 			// no digits (e.g. ".s" or "-.s")
-			err = ErrInvalidDuration{String: orig}
+			err = newErrInvalidDuration(orig, start, len(orig)-len(s)-start, ReasonMissingDigits)
 			return
 		}
 
 		// Consume unit.
 		if s == "" {
-			err = ErrInvalidDuration{String: orig}
+			err = newErrInvalidDuration(orig, len(orig), 0, ReasonMissingDesignator)
 			return
 		}
 		var u = s[0]
+		var uOffset = len(orig) - len(s)
 		s = s[1:]
+		var order int
+		var overflow error
 		if !afterT {
 			switch u {
 			case 'Y':
-				ret.Years += v
-				ret.Months += int64(float64(f) * (float64(Year/Month) / scale))
+				order = 1
+				ret.Years, overflow = addInt(ret.Years, v)
 			case 'M':
-				ret.Months += v
-				ret.Weeks += int64(float64(f) * (float64(Month/Week) / scale))
+				order = 2
+				ret.Months, overflow = addInt(ret.Months, v)
 			case 'W':
-				ret.Weeks += v
-				ret.Days += int64(float64(f) * (float64(Week/Day) / scale))
+				order = 3
+				hasWeeks = true
+				ret.Weeks, overflow = addInt(ret.Weeks, v)
 			case 'D':
-				ret.Days += v
-				ret.Hours += int64(float64(f) * (float64(Day/time.Hour) / scale))
+				order = 4
+				ret.Days, overflow = addInt(ret.Days, v)
 			default:
 				// unknown unit
-				err = ErrInvalidDuration{String: orig}
+				err = newErrInvalidDuration(orig, uOffset, 1, ReasonUnknownDesignator)
 				return
 			}
 		} else {
 			switch u {
 			case 'H':
-				ret.Hours += v
-				ret.Minutes += int64(float64(f) * (float64(time.Hour/time.Minute) / scale))
+				order = 5
+				ret.Hours, overflow = addInt(ret.Hours, v)
 			case 'M':
-				ret.Minutes += v
-				ret.Seconds += int64(float64(f) * (float64(time.Minute/time.Second) / scale))
+				order = 6
+				ret.Minutes, overflow = addInt(ret.Minutes, v)
 			case 'S':
-				ret.Seconds += v
-				ret.Nanoseconds += int64(float64(f) * (float64(time.Second/time.Nanosecond) / scale))
+				order = 7
+				ret.Seconds, overflow = addInt(ret.Seconds, v)
 			default:
 				// unknown unit
-				err = ErrInvalidDuration{String: orig}
+				err = newErrInvalidDuration(orig, uOffset, 1, ReasonUnknownDesignator)
+				return
+			}
+		}
+		if overflow == nil && post {
+			// order is index of nominalSizes plus one
+			var nano = fractionOf(digits, nominalSizes[order-1])
+			if neg {
+				nano = -nano
+			}
+			overflow = spreadNano(&ret, order-1, nano)
+		}
+		if overflow != nil {
+			err = newErrInvalidDuration(orig, start, uOffset+1-start, ReasonOverflow)
+			return
+		}
+		if opts.Strict {
+			if order == lastOrder {
+				err = newErrInvalidDuration(orig, uOffset, 1, ReasonRepeatedDesignator)
+				return
+			}
+			if order < lastOrder {
+				err = newErrInvalidDuration(orig, uOffset, 1, ReasonOutOfOrder)
+				return
+			}
+			if hasWeeks && lastOrder != 0 {
+				err = newErrInvalidDuration(orig, uOffset, 1, ReasonWeeksCombined)
 				return
 			}
 		}
+		lastOrder = order
 
 		if post && s != "" {
 			// must end after fraction used.
-			err = ErrInvalidDuration{String: orig}
+			err = newErrInvalidDuration(orig, start, uOffset+1-start, ReasonFractionNotLast)
 			return
 		}
 	}
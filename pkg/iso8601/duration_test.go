@@ -1,6 +1,8 @@
 package iso8601
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -24,6 +26,11 @@ func TestDurationString(t *testing.T) {
 		{duration: Duration{Seconds: 1}, expected: "PT1S"},
 		{duration: Duration{Nanoseconds: 1}, expected: "PT0.000000001S"},
 		{duration: Duration{Nanoseconds: 1e3}, expected: "PT0.000001S"},
+		{duration: Duration{Nanoseconds: 15e8}, expected: "PT1.5S"},
+		{duration: Duration{Seconds: 1, Nanoseconds: -15e8}, expected: "PT-0.5S"},
+		{duration: Duration{Nanoseconds: maxInt64}, expected: "PT9223372036.854775807S"},
+		{duration: Duration{Nanoseconds: minInt64}, expected: "PT-9223372036.854775808S"},
+		{duration: Duration{Seconds: maxInt64, Nanoseconds: 5e8}, expected: "PT9223372036854775807.5S"},
 		{duration: Duration{Minutes: 1, Seconds: 1}, expected: "PT1M1S"},
 		{duration: Duration{Negative: true}, expected: "-P0D"},
 		{duration: Duration{Hours: -1}, expected: "PT-1H"},
@@ -58,6 +65,23 @@ func TestDurationString(t *testing.T) {
 	}
 }
 
+func TestDurationAppendFormatComma(t *testing.T) {
+	for _, c := range []struct {
+		duration Duration
+		expected string
+	}{
+		{duration: Duration{}, expected: "P0D"},
+		{duration: Duration{Seconds: 1}, expected: "PT1S"},
+		{duration: Duration{Nanoseconds: 5e8}, expected: "PT0,5S"},
+		{duration: Duration{Seconds: -1, Nanoseconds: -25e7}, expected: "PT-1,25S"},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			var v = string(c.duration.AppendFormat(nil, FormatOptionComma()))
+			assert.Equal(t, c.expected, v)
+		})
+	}
+}
+
 func TestDurationTimeDuration(t *testing.T) {
 	for _, c := range []struct {
 		duration Duration
@@ -182,12 +206,32 @@ func TestParseDuration(t *testing.T) {
 		{s: "PT1H1M1S", expected: Duration{Hours: 1, Minutes: 1, Seconds: 1}},
 		{s: "PT0.5H", expected: Duration{Minutes: 30}},
 		{s: "PT0.001S", expected: Duration{Nanoseconds: 1e6}},
+		{s: "PT0,5H", expected: Duration{Minutes: 30}},
+		{s: "PT1,001S", expected: Duration{Seconds: 1, Nanoseconds: 1e6}},
+		{s: "P0.5Y", expected: Duration{Months: 6}},
+		{s: "P0.5M", expected: Duration{Weeks: 2, Days: 1, Hours: 5, Minutes: 14, Seconds: 33}},
+		{s: "P0.333Y", expected: Duration{Months: 3, Weeks: 4, Days: 2, Hours: 7, Minutes: 33, Seconds: 47, Nanoseconds: 16e6}},
+		{s: "P-0.1Y", expected: Duration{Months: -1, Days: -6, Hours: -2, Minutes: -5, Seconds: -49, Nanoseconds: -2e8}},
+		{s: "P0.5W", expected: Duration{Days: 3, Hours: 12}},
+		{s: "P0.3D", expected: Duration{Hours: 7, Minutes: 12}},
+		{s: "PT0.1S", expected: Duration{Nanoseconds: 1e8}},
+		{s: "PT0.999999999999999999999999999S", expected: Duration{Nanoseconds: 999999999}},
+		{s: "P0.0000000000000000000001Y", expected: Duration{}},
+		{s: "P1.999999999999999999999Y", expected: Duration{Years: 1, Months: 11, Weeks: 4, Days: 2, Hours: 10, Minutes: 29, Seconds: 5, Nanoseconds: 999999999}},
+		{s: "PT9223372036854775807H0.5M", expected: Duration{Hours: maxInt64, Seconds: 30}},
 		{s: "-PT1H", expected: Duration{Hours: 1, Negative: true}},
 		{s: "+PT1H", expected: Duration{Hours: 1}},
-		{s: "", err: ErrInvalidDuration{String: ""}},
-		{s: "-", expected: Duration{Negative: true}, err: ErrInvalidDuration{String: "-"}},
-		{s: "+", err: ErrInvalidDuration{String: "+"}},
-		{s: "1D", err: ErrInvalidDuration{String: "1D"}},
+		{s: "", err: ErrInvalidDuration{String: "", Offset: 0, Token: "", Reason: ReasonMissingDesignator}},
+		{s: "-", expected: Duration{Negative: true}, err: ErrInvalidDuration{String: "-", Offset: 1, Token: "", Reason: ReasonMissingDesignator}},
+		{s: "+", err: ErrInvalidDuration{String: "+", Offset: 1, Token: "", Reason: ReasonMissingDesignator}},
+		{s: "1D", err: ErrInvalidDuration{String: "1D", Offset: 0, Token: "", Reason: ReasonMissingDesignator}},
+		{s: "P1", expected: Duration{}, err: ErrInvalidDuration{String: "P1", Offset: 2, Token: "", Reason: ReasonMissingDesignator}},
+		{s: "P-D", err: ErrInvalidDuration{String: "P-D", Offset: 1, Token: "-", Reason: ReasonMissingDigits}},
+		{s: "P1X", err: ErrInvalidDuration{String: "P1X", Offset: 2, Token: "X", Reason: ReasonUnknownDesignator}},
+		{s: "PT1D", err: ErrInvalidDuration{String: "PT1D", Offset: 3, Token: "D", Reason: ReasonUnknownDesignator}},
+		{s: "P0.5D1H", expected: Duration{Hours: 12}, err: ErrInvalidDuration{String: "P0.5D1H", Offset: 1, Token: "0.5D", Reason: ReasonFractionNotLast}},
+		{s: "P99999999999999999999D", err: ErrInvalidDuration{String: "P99999999999999999999D", Offset: 1, Token: "99999999999999999999D", Reason: ReasonOverflow}},
+		{s: "P9223372036854775807D1D", err: ErrInvalidDuration{String: "P9223372036854775807D1D", Offset: 21, Token: "1D", Reason: ReasonOverflow}},
 	} {
 		t.Run(c.s, func(t *testing.T) {
 			v, err := ParseDuration(c.s)
@@ -197,6 +241,192 @@ func TestParseDuration(t *testing.T) {
 	}
 }
 
+func TestFractionOf(t *testing.T) {
+	for _, c := range []struct {
+		digits   string
+		unit     time.Duration
+		expected int64
+	}{
+		{digits: "", unit: time.Second, expected: 0},
+		{digits: "5", unit: time.Second, expected: 5e8},
+		{digits: "123456789123", unit: time.Second, expected: 123456789},
+		{digits: "5", unit: Year, expected: int64(Year / 2)},
+		{digits: "123456789012345678901234567890", unit: Year, expected: 3895919964936719},
+		{digits: "999999999999999999999999999999999999999", unit: Year, expected: int64(Year - 1)},
+	} {
+		t.Run(c.digits, func(t *testing.T) {
+			assert.Equal(t, c.expected, fractionOf(c.digits, c.unit))
+		})
+	}
+}
+
+func TestAddInt(t *testing.T) {
+	for _, c := range []struct {
+		base, v  int64
+		expected int64
+		err      error
+	}{
+		{base: 1, v: 2, expected: 3},
+		{base: 0, v: maxInt64, expected: maxInt64},
+		{base: 0, v: minInt64, expected: minInt64},
+		{base: maxInt64, v: 0, expected: maxInt64},
+		{base: maxInt64, v: minInt64, expected: -1},
+		{base: minInt64, v: maxInt64, expected: -1},
+		{base: maxInt64 - 1, v: 1, expected: maxInt64},
+		{base: minInt64 + 1, v: -1, expected: minInt64},
+		{base: maxInt64, v: 1, err: ErrOverflow},
+		{base: 1, v: maxInt64, err: ErrOverflow},
+		{base: minInt64, v: -1, err: ErrOverflow},
+		{base: -1, v: minInt64, err: ErrOverflow},
+		{base: minInt64, v: minInt64, err: ErrOverflow},
+		{base: maxInt64, v: maxInt64, err: ErrOverflow},
+	} {
+		t.Run(fmt.Sprintf("%d+%d", c.base, c.v), func(t *testing.T) {
+			v, err := addInt(c.base, c.v)
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+}
+
+func TestMultiplyInt(t *testing.T) {
+	for _, c := range []struct {
+		base, v  int64
+		expected int64
+		err      error
+	}{
+		{base: 2, v: 3, expected: 6},
+		{base: 0, v: minInt64, expected: 0},
+		{base: minInt64, v: 0, expected: 0},
+		{base: 1, v: minInt64, expected: minInt64},
+		{base: minInt64, v: 1, expected: minInt64},
+		{base: -1, v: maxInt64, expected: -maxInt64},
+		{base: maxInt64, v: -1, expected: -maxInt64},
+		{base: 2, v: minInt64 / 2, expected: minInt64},
+		{base: -2, v: minInt64 / -2, expected: minInt64},
+		{base: -2, v: maxInt64 / -2, expected: maxInt64 - 1},
+		{base: -1, v: minInt64, err: ErrOverflow},
+		{base: minInt64, v: -1, err: ErrOverflow},
+		{base: 2, v: maxInt64/2 + 1, err: ErrOverflow},
+		{base: 2, v: minInt64/2 - 1, err: ErrOverflow},
+		{base: -2, v: minInt64/-2 + 1, err: ErrOverflow},
+		{base: -2, v: maxInt64/-2 - 1, err: ErrOverflow},
+		{base: minInt64, v: minInt64, err: ErrOverflow},
+	} {
+		t.Run(fmt.Sprintf("%d*%d", c.base, c.v), func(t *testing.T) {
+			v, err := multiplyInt(c.base, c.v)
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+}
+
+func TestNegateInt(t *testing.T) {
+	for _, c := range []struct {
+		v        int64
+		expected int64
+		err      error
+	}{
+		{v: 0, expected: 0},
+		{v: 1, expected: -1},
+		{v: maxInt64, expected: -maxInt64},
+		{v: minInt64 + 1, expected: maxInt64},
+		{v: minInt64, err: ErrOverflow},
+	} {
+		t.Run(fmt.Sprint(c.v), func(t *testing.T) {
+			v, err := negateInt(c.v)
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+}
+
+func TestParseDurationStrict(t *testing.T) {
+	for _, c := range []struct {
+		s        string
+		expected Duration
+		err      error
+	}{
+		{s: "P1Y2M3DT4H5M6.7S", expected: Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 7e8}},
+		{s: "P1W", expected: Duration{Weeks: 1}},
+		{s: "PT0.5H", expected: Duration{Minutes: 30}},
+		{s: "-P1D", expected: Duration{Days: 1, Negative: true}},
+		{s: "+P1D", err: ErrInvalidDuration{String: "+P1D", Offset: 0, Token: "+", Reason: ReasonSign}},
+		{s: "P", err: ErrInvalidDuration{String: "P", Offset: 1, Token: "", Reason: ReasonNoComponent}},
+		{s: "P-1D", err: ErrInvalidDuration{String: "P-1D", Offset: 1, Token: "-", Reason: ReasonSign}},
+		{s: "PT+1H", err: ErrInvalidDuration{String: "PT+1H", Offset: 2, Token: "+", Reason: ReasonSign}},
+		{s: "P1D1D", err: ErrInvalidDuration{String: "P1D1D", Offset: 4, Token: "D", Reason: ReasonRepeatedDesignator}},
+		{s: "PT1S1H", err: ErrInvalidDuration{String: "PT1S1H", Offset: 5, Token: "H", Reason: ReasonOutOfOrder}},
+		{s: "P1D1M", err: ErrInvalidDuration{String: "P1D1M", Offset: 4, Token: "M", Reason: ReasonOutOfOrder}},
+		{s: "P1DT", err: ErrInvalidDuration{String: "P1DT", Offset: 3, Token: "T", Reason: ReasonEmptyTimeSection}},
+		{s: "PT", err: ErrInvalidDuration{String: "PT", Offset: 1, Token: "T", Reason: ReasonEmptyTimeSection}},
+		{s: "PT1HT1M", err: ErrInvalidDuration{String: "PT1HT1M", Offset: 4, Token: "T", Reason: ReasonRepeatedDesignator}},
+		{s: "P1W1D", err: ErrInvalidDuration{String: "P1W1D", Offset: 4, Token: "D", Reason: ReasonWeeksCombined}},
+		{s: "P1Y1W", err: ErrInvalidDuration{String: "P1Y1W", Offset: 4, Token: "W", Reason: ReasonWeeksCombined}},
+		{s: "P.5D", err: ErrInvalidDuration{String: "P.5D", Offset: 1, Token: ".5", Reason: ReasonMissingDigits}},
+		{s: "P1.D", err: ErrInvalidDuration{String: "P1.D", Offset: 1, Token: "1.", Reason: ReasonMissingDigits}},
+		{s: "P1X", err: ErrInvalidDuration{String: "P1X", Offset: 2, Token: "X", Reason: ReasonUnknownDesignator}},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseDuration(c.s, ParseDurationOptionStrict())
+			require.Equal(t, c.err, err)
+			if err == nil {
+				assert.Equal(t, c.expected, v)
+				// lenient mode accepts everything strict mode accepts.
+				v, err = ParseDuration(c.s)
+				require.NoError(t, err)
+				assert.Equal(t, c.expected, v)
+			}
+		})
+	}
+}
+
+func TestErrInvalidDuration(t *testing.T) {
+	for _, c := range []struct {
+		err      ErrInvalidDuration
+		expected string
+	}{
+		{err: ErrInvalidDuration{String: "x"}, expected: "iso8601: invalid duration x"},
+		{err: ErrInvalidDuration{String: "P1D1D", Offset: 4, Token: "D", Reason: ReasonRepeatedDesignator}, expected: `iso8601: invalid duration P1D1D: repeated designator "D" at offset 4`},
+		{err: ErrInvalidDuration{String: "P1", Offset: 2, Token: "", Reason: ReasonMissingDesignator}, expected: `iso8601: invalid duration P1: missing designator at offset 2`},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.err.Error())
+		})
+	}
+
+	_, err := ParseDuration("P99999999999999999999D")
+	assert.True(t, errors.Is(err, ErrOverflow))
+	var e ErrInvalidDuration
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, "99999999999999999999D", e.Token)
+
+	_, err = ParseDuration("P1X")
+	assert.False(t, errors.Is(err, ErrOverflow))
+	assert.IsType(t, ErrInvalidDuration{}, err)
+}
+
+func TestParseDurationLenient(t *testing.T) {
+	for _, c := range []struct {
+		s        string
+		expected Duration
+	}{
+		{s: "P", expected: Duration{}},
+		{s: "P1D1D", expected: Duration{Days: 2}},
+		{s: "PT1S1H", expected: Duration{Hours: 1, Seconds: 1}},
+		{s: "P1DT", expected: Duration{Days: 1}},
+		{s: "P1W1D", expected: Duration{Weeks: 1, Days: 1}},
+		{s: "P.5D", expected: Duration{Hours: 12}},
+		{s: "P1.D", expected: Duration{Days: 1}},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseDuration(c.s)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+}
+
 func BenchmarkDurationStringLen22(b *testing.B) {
 	// -P1Y-2M3W-4DT5H-6M7.8S
 	x := Duration{
@@ -0,0 +1,121 @@
+package iso8601
+
+import (
+	"encoding/json"
+)
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return d.AppendFormat(make([]byte, 0, 32)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(data []byte) error {
+	var v, err = ParseDuration(string(data))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	var b = make([]byte, 0, 32)
+	b = append(b, '"')
+	b = d.AppendFormat(b)
+	b = append(b, '"')
+	return b, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// null is ignored like other json.Unmarshaler in standard library.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	var err = json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// NullDuration represents a Duration that may be null,
+// like sql.NullString.
+type NullDuration struct {
+	Duration Duration
+	// Valid is true if Duration is not null
+	Valid bool
+}
+
+// NewNullDuration create NullDuration from pointer, nil pointer means null.
+func NewNullDuration(p *Duration) NullDuration {
+	if p == nil {
+		return NullDuration{}
+	}
+	return NullDuration{Duration: *p, Valid: true}
+}
+
+// Ptr returns pointer to a copy of Duration, or nil if null.
+func (n NullDuration) Ptr() *Duration {
+	if !n.Valid {
+		return nil
+	}
+	var d = n.Duration
+	return &d
+}
+
+func (n NullDuration) String() string {
+	if !n.Valid {
+		return ""
+	}
+	return n.Duration.String()
+}
+
+// MarshalText implements encoding.TextMarshaler,
+// null is represented as empty text.
+func (n NullDuration) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return n.Duration.MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler,
+// empty text is null.
+func (n *NullDuration) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*n = NullDuration{}
+		return nil
+	}
+	var err = n.Duration.UnmarshalText(data)
+	if err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullDuration) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Duration.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullDuration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = NullDuration{}
+		return nil
+	}
+	var err = n.Duration.UnmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
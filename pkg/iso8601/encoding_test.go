@@ -0,0 +1,147 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationJSON(t *testing.T) {
+	type payload struct {
+		D Duration  `json:"d"`
+		P *Duration `json:"p"`
+	}
+	for _, c := range []struct {
+		value    payload
+		expected string
+	}{
+		{value: payload{}, expected: `{"d":"P0D","p":null}`},
+		{value: payload{D: Duration{Days: 1}, P: &Duration{Hours: 1, Negative: true}}, expected: `{"d":"P1D","p":"-PT1H"}`},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			data, err := json.Marshal(c.value)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, string(data))
+
+			var v payload
+			err = json.Unmarshal(data, &v)
+			require.NoError(t, err)
+			assert.Equal(t, c.value, v)
+		})
+	}
+}
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	for _, c := range []struct {
+		data     string
+		expected Duration
+		err      error
+	}{
+		{data: `"PT1H"`, expected: Duration{Hours: 1}},
+		{data: `null`, expected: Duration{Days: 1}},
+		{data: `"1D"`, expected: Duration{Days: 1}, err: ErrInvalidDuration{String: "1D", Offset: 0, Token: "", Reason: ReasonMissingDesignator}},
+	} {
+		t.Run(c.data, func(t *testing.T) {
+			var v = Duration{Days: 1}
+			err := v.UnmarshalJSON([]byte(c.data))
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+
+	var v struct {
+		D Duration
+	}
+	err := json.Unmarshal([]byte(`{"D":"P"}`), &v)
+	assert.NoError(t, err)
+	err = json.Unmarshal([]byte(`{"D":1}`), &v)
+	assert.Error(t, err)
+	err = json.Unmarshal([]byte(`{"D":"X"}`), &v)
+	assert.IsType(t, ErrInvalidDuration{}, err)
+}
+
+func TestDurationText(t *testing.T) {
+	type payload struct {
+		D Duration `xml:"d,attr"`
+		E Duration `xml:"e"`
+	}
+	var value = payload{D: Duration{Days: 1}, E: Duration{Minutes: 1, Seconds: 30}}
+	data, err := xml.Marshal(value)
+	require.NoError(t, err)
+	assert.Equal(t, `<payload d="P1D"><e>PT1M30S</e></payload>`, string(data))
+
+	var v payload
+	err = xml.Unmarshal(data, &v)
+	require.NoError(t, err)
+	assert.Equal(t, value, v)
+
+	err = v.D.UnmarshalText([]byte("P1X"))
+	assert.Equal(t, ErrInvalidDuration{String: "P1X", Offset: 2, Token: "X", Reason: ReasonUnknownDesignator}, err)
+	assert.Equal(t, Duration{Days: 1}, v.D)
+}
+
+func TestNullDuration(t *testing.T) {
+	type payload struct {
+		D NullDuration `json:"d"`
+	}
+	for _, c := range []struct {
+		value    payload
+		expected string
+	}{
+		{value: payload{}, expected: `{"d":null}`},
+		{value: payload{D: NullDuration{Valid: true}}, expected: `{"d":"P0D"}`},
+		{value: payload{D: NullDuration{Duration: Duration{Weeks: 2}, Valid: true}}, expected: `{"d":"P2W"}`},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			data, err := json.Marshal(c.value)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, string(data))
+
+			var v = payload{D: NullDuration{Duration: Duration{Days: 1}, Valid: true}}
+			err = json.Unmarshal(data, &v)
+			require.NoError(t, err)
+			assert.Equal(t, c.value, v)
+		})
+	}
+
+	var v NullDuration
+	err := v.UnmarshalJSON([]byte(`"P1X"`))
+	assert.Equal(t, ErrInvalidDuration{String: "P1X", Offset: 2, Token: "X", Reason: ReasonUnknownDesignator}, err)
+	assert.False(t, v.Valid)
+
+	err = v.UnmarshalText([]byte("PT1S"))
+	require.NoError(t, err)
+	assert.Equal(t, NullDuration{Duration: Duration{Seconds: 1}, Valid: true}, v)
+	assert.Equal(t, "PT1S", v.String())
+	data, err := v.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "PT1S", string(data))
+
+	err = v.UnmarshalText(nil)
+	require.NoError(t, err)
+	assert.Equal(t, NullDuration{}, v)
+	assert.Equal(t, "", v.String())
+	data, err = v.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "", string(data))
+}
+
+func TestNullDurationPtr(t *testing.T) {
+	assert.Nil(t, NullDuration{}.Ptr())
+	assert.Equal(t, &Duration{Days: 1}, NullDuration{Duration: Duration{Days: 1}, Valid: true}.Ptr())
+	assert.Equal(t, NullDuration{}, NewNullDuration(nil))
+	assert.Equal(t, NullDuration{Duration: Duration{Days: 1}, Valid: true}, NewNullDuration(&Duration{Days: 1}))
+}
+
+func BenchmarkDurationMarshalJSON(b *testing.B) {
+	x := Duration{Years: 1, Months: 2, Weeks: 3, Days: 4, Hours: 5, Minutes: 6, Seconds: 7, Nanoseconds: 8e8}
+	for i := 0; i < b.N; i++ {
+		_, err := x.MarshalJSON()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package iso8601
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+)
+
+// ErrOutOfRange returned by DurationValue.Set when value exceeds bounds.
+var ErrOutOfRange = errors.New("iso8601: out of range")
+
+// FlagOptions for DurationValue.
+type FlagOptions struct {
+	// Min and Max are inclusive bounds, nil means unbounded.
+	Min, Max *Duration
+	// Parse is options for ParseDuration.
+	Parse []ParseDurationOption
+}
+
+// FlagOption mutate FlagOptions.
+type FlagOption func(opts *FlagOptions)
+
+// FlagOptionMin reject value less than min.
+// Values are compared by TimeDuration, so calendar components use nominal length.
+func FlagOptionMin(min Duration) FlagOption {
+	return func(opts *FlagOptions) {
+		opts.Min = &min
+	}
+}
+
+// FlagOptionMax reject value greater than max.
+// Values are compared by TimeDuration, so calendar components use nominal length.
+func FlagOptionMax(max Duration) FlagOption {
+	return func(opts *FlagOptions) {
+		opts.Max = &max
+	}
+}
+
+// FlagOptionParse set options used to parse value.
+func FlagOptionParse(options ...ParseDurationOption) FlagOption {
+	return func(opts *FlagOptions) {
+		opts.Parse = options
+	}
+}
+
+func newFlagOptions(options []FlagOption) FlagOptions {
+	var opts = new(FlagOptions)
+	for _, i := range options {
+		i(opts)
+	}
+	return *opts
+}
+
+// DurationValue implements flag.Value and flag.Getter for Duration,
+// it also has Type method for github.com/spf13/pflag.
+type DurationValue struct {
+	p    *Duration
+	opts FlagOptions
+}
+
+var _ flag.Getter = (*DurationValue)(nil)
+
+// NewDurationValue returns flag value that stores into p,
+// p is set to value.
+func NewDurationValue(p *Duration, value Duration, options ...FlagOption) *DurationValue {
+	*p = value
+	return &DurationValue{p: p, opts: newFlagOptions(options)}
+}
+
+// checkBounds returns error wraps ErrOutOfRange when d exceeds bounds.
+func (opts FlagOptions) checkBounds(d Duration) (err error) {
+	if opts.Min == nil && opts.Max == nil {
+		return
+	}
+	var v, min, max time.Duration
+	v, err = d.TimeDuration()
+	if err != nil {
+		return
+	}
+	if opts.Min != nil {
+		min, err = opts.Min.TimeDuration()
+		if err != nil {
+			return
+		}
+		if v < min {
+			return fmt.Errorf("%w: %s is less than %s", ErrOutOfRange, d, opts.Min)
+		}
+	}
+	if opts.Max != nil {
+		max, err = opts.Max.TimeDuration()
+		if err != nil {
+			return
+		}
+		if v > max {
+			return fmt.Errorf("%w: %s is greater than %s", ErrOutOfRange, d, opts.Max)
+		}
+	}
+	return
+}
+
+// Set implements flag.Value.
+func (v *DurationValue) Set(s string) error {
+	var d, err = ParseDuration(s, v.opts.Parse...)
+	if err != nil {
+		return err
+	}
+	err = v.opts.checkBounds(d)
+	if err != nil {
+		return err
+	}
+	*v.p = d
+	return nil
+}
+
+// String implements flag.Value.
+func (v *DurationValue) String() string {
+	if v == nil || v.p == nil {
+		return ""
+	}
+	return v.p.String()
+}
+
+// Get implements flag.Getter, returns Duration.
+func (v *DurationValue) Get() interface{} {
+	return *v.p
+}
+
+// Type returns value type name for github.com/spf13/pflag.
+func (v *DurationValue) Type() string {
+	return "duration"
+}
+
+// DurationVar defines a Duration flag with specified name, default value, and usage string
+// in flag.CommandLine, like flag.DurationVar.
+// The argument p points to a Duration variable in which to store the value of the flag.
+//
+// Use NewDurationValue with FlagSet.Var for other flag set.
+func DurationVar(p *Duration, name string, value Duration, usage string, options ...FlagOption) {
+	flag.CommandLine.Var(NewDurationValue(p, value, options...), name, usage)
+}
+
+// DurationFlag defines a Duration flag with specified name, default value, and usage string
+// in flag.CommandLine, like flag.Duration.
+// The return value is the address of a Duration variable that stores the value of the flag.
+func DurationFlag(name string, value Duration, usage string, options ...FlagOption) *Duration {
+	var p = new(Duration)
+	DurationVar(p, name, value, usage, options...)
+	return p
+}
@@ -0,0 +1,93 @@
+package iso8601
+
+import (
+	"errors"
+	"flag"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationValueSet(t *testing.T) {
+	for _, c := range []struct {
+		s        string
+		options  []FlagOption
+		expected Duration
+		err      error
+	}{
+		{s: "PT1H", expected: Duration{Hours: 1}},
+		{s: "P1D1D", expected: Duration{Days: 2}},
+		{
+			s:        "P1D1D",
+			options:  []FlagOption{FlagOptionParse(ParseDurationOptionStrict())},
+			expected: Duration{Days: 1},
+			err:      ErrInvalidDuration{String: "P1D1D", Offset: 4, Token: "D", Reason: ReasonRepeatedDesignator},
+		},
+		{s: "1h", expected: Duration{Days: 1}, err: ErrInvalidDuration{String: "1h", Reason: ReasonMissingDesignator}},
+		{s: "PT1H", options: []FlagOption{FlagOptionMin(Duration{Hours: 1}), FlagOptionMax(Duration{Days: 7})}, expected: Duration{Hours: 1}},
+		{s: "P1W", options: []FlagOption{FlagOptionMin(Duration{Hours: 1}), FlagOptionMax(Duration{Days: 7})}, expected: Duration{Weeks: 1}},
+		{s: "PT59M", options: []FlagOption{FlagOptionMin(Duration{Hours: 1})}, expected: Duration{Days: 1}, err: ErrOutOfRange},
+		{s: "P8D", options: []FlagOption{FlagOptionMax(Duration{Weeks: 1})}, expected: Duration{Days: 1}, err: ErrOutOfRange},
+		{s: "P1000Y", options: []FlagOption{FlagOptionMax(Duration{Weeks: 1})}, expected: Duration{Days: 1}, err: ErrOverflow},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			var d Duration
+			var v = NewDurationValue(&d, Duration{Days: 1}, c.options...)
+			err := v.Set(c.s)
+			if c.err == ErrOutOfRange {
+				assert.True(t, errors.Is(err, ErrOutOfRange))
+			} else {
+				require.Equal(t, c.err, err)
+			}
+			assert.Equal(t, c.expected, d)
+		})
+	}
+}
+
+func TestDurationValueFlagSet(t *testing.T) {
+	var fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	var d Duration
+	fs.Var(NewDurationValue(&d, Duration{Days: 1}, FlagOptionMax(Duration{Weeks: 1})), "d", "duration")
+	require.NoError(t, fs.Parse([]string{"-d", "PT1H"}))
+	assert.Equal(t, Duration{Hours: 1}, d)
+	require.NoError(t, fs.Parse([]string{"-d=P2D"}))
+	assert.Equal(t, Duration{Days: 2}, d)
+	assert.Error(t, fs.Parse([]string{"-d=P8D"}))
+	assert.Equal(t, Duration{Days: 2}, d)
+	assert.Equal(t, "P1D", fs.Lookup("d").DefValue)
+}
+
+func TestDurationValueMethods(t *testing.T) {
+	var d Duration
+	var v = NewDurationValue(&d, Duration{Hours: 2})
+	assert.Equal(t, "PT2H", v.String())
+	assert.Equal(t, Duration{Hours: 2}, v.Get())
+	assert.Equal(t, "duration", v.Type())
+	assert.Equal(t, "", (*DurationValue)(nil).String())
+	assert.Equal(t, "", new(DurationValue).String())
+
+	err := v.Set("PT1M")
+	require.NoError(t, err)
+	assert.Equal(t, Duration{Minutes: 1}, d)
+
+	v = NewDurationValue(&d, Duration{}, FlagOptionMin(Duration{Hours: 1}))
+	err = v.Set("PT1M")
+	assert.EqualError(t, err, "iso8601: out of range: PT1M is less than PT1H")
+}
+
+func TestDurationVar(t *testing.T) {
+	var d Duration
+	DurationVar(&d, "test-duration-var", Duration{Days: 1}, "usage")
+	var p = DurationFlag("test-duration-flag", Duration{Hours: 1}, "usage")
+	assert.Equal(t, Duration{Days: 1}, d)
+	assert.Equal(t, Duration{Hours: 1}, *p)
+
+	require.NoError(t, flag.CommandLine.Set("test-duration-var", "P2D"))
+	require.NoError(t, flag.CommandLine.Set("test-duration-flag", "PT2H"))
+	assert.Equal(t, Duration{Days: 2}, d)
+	assert.Equal(t, Duration{Hours: 2}, *p)
+	assert.Equal(t, "P1D", flag.CommandLine.Lookup("test-duration-var").DefValue)
+}
@@ -0,0 +1,399 @@
+package iso8601
+
+import (
+	"math/bits"
+	"time"
+)
+
+// FormatOptions for formatting.
+type FormatOptions struct {
+	// Alternative use alternative duration format PYYYY-MM-DDThh:mm:ss
+	// when value fits in it.
+	Alternative bool
+	// Basic use basic format that omits separators.
+	Basic bool
+	// Comma use ',' instead of '.' as decimal sign.
+	Comma bool
+	// MinFractionDigits pads fraction with zero to at least this digits.
+	MinFractionDigits int
+	// MaxFractionDigits truncates fraction toward zero to at most this digits,
+	// defaults to 9.
+	MaxFractionDigits int
+	// Units restricts units in output, zero means UnitAll.
+	Units Unit
+	// FoldFraction folds smaller components into fraction of larger component
+	// when it is exact (e.g. PT1.5H instead of PT1H30M).
+	FoldFraction bool
+	// WeekDate use week date (e.g. 2001-W05-6) for time.
+	WeekDate bool
+	// OrdinalDate use ordinal date (e.g. 2001-034) for time.
+	OrdinalDate bool
+	// ZeroUnit is the unit used to represent zero duration,
+	// zero means UnitDay (P0D), or smallest unit of Units when UnitDay is not in it.
+	ZeroUnit Unit
+}
+
+var defaultFormatOptions = FormatOptions{MaxFractionDigits: 9}
+
+func (opts FormatOptions) decimalSign() byte {
+	if opts.Comma {
+		return ','
+	}
+	return '.'
+}
+
+// FormatOption mutate FormatOptions.
+type FormatOption func(opts *FormatOptions)
+
+// FormatOptionAlternative format duration in alternative format
+// (e.g. P0003-06-04T12:30:05) when it fits in,
+// that requires no weeks, no negative component and no component exceeds its carry-over point
+// (9999 years, 12 months, 30 days, 24 hours, 60 minutes, 60 seconds).
+func FormatOptionAlternative() FormatOption {
+	return func(opts *FormatOptions) {
+		opts.Alternative = true
+	}
+}
+
+// FormatOptionBasic use basic format that omits separators
+// (e.g. P00030604T123005 for alternative duration format).
+func FormatOptionBasic() FormatOption {
+	return func(opts *FormatOptions) {
+		opts.Basic = true
+	}
+}
+
+// FormatOptionComma use ',' as decimal sign (e.g. PT0,5S),
+// which is preferred by ISO 8601.
+func FormatOptionComma() FormatOption {
+	return func(opts *FormatOptions) {
+		opts.Comma = true
+	}
+}
+
+// FormatOptionWeekDate format time with week date (e.g. 2001-W05-6T04:05:06Z).
+func FormatOptionWeekDate() FormatOption {
+	return func(opts *FormatOptions) {
+		opts.WeekDate = true
+	}
+}
+
+// FormatOptionOrdinalDate format time with ordinal date (e.g. 2001-034T04:05:06Z).
+func FormatOptionOrdinalDate() FormatOption {
+	return func(opts *FormatOptions) {
+		opts.OrdinalDate = true
+	}
+}
+
+// FormatOptionFractionDigits use fixed n digits for fraction,
+// e.g. PT1.500S for n = 3.
+func FormatOptionFractionDigits(n int) FormatOption {
+	return func(opts *FormatOptions) {
+		opts.MinFractionDigits = n
+		opts.MaxFractionDigits = n
+	}
+}
+
+// FormatOptionMaxFractionDigits use at most n digits for fraction,
+// fraction is truncated toward zero and trailing zeros are omitted.
+func FormatOptionMaxFractionDigits(n int) FormatOption {
+	return func(opts *FormatOptions) {
+		opts.MaxFractionDigits = n
+		if opts.MinFractionDigits > n {
+			opts.MinFractionDigits = n
+		}
+	}
+}
+
+// FormatOptionUnits only use given units in output,
+// other components are converted with nominal units (see Year, Month, Week and Day),
+// remainder smaller than smallest unit is written as fraction of it.
+// If conversion overflows, duration is formatted without this option.
+func FormatOptionUnits(units Unit) FormatOption {
+	return func(opts *FormatOptions) {
+		opts.Units = units
+	}
+}
+
+// FormatOptionWeeks only use weeks in output (e.g. P2W),
+// same as FormatOptionUnits(UnitWeek).
+func FormatOptionWeeks() FormatOption {
+	return FormatOptionUnits(UnitWeek)
+}
+
+// FormatOptionFoldFraction folds smaller components into fraction of smallest emitted unit
+// when result is exact and parsed back to same value (e.g. PT1.5H instead of PT1H30M).
+func FormatOptionFoldFraction() FormatOption {
+	return func(opts *FormatOptions) {
+		opts.FoldFraction = true
+	}
+}
+
+// FormatOptionZeroUnit set unit used to represent zero duration,
+// e.g. UnitSecond for PT0S.
+func FormatOptionZeroUnit(unit Unit) FormatOption {
+	return func(opts *FormatOptions) {
+		opts.ZeroUnit = unit
+	}
+}
+
+func newFormatOptions(options []FormatOption) FormatOptions {
+	var opts = new(FormatOptions)
+	*opts = defaultFormatOptions
+	for _, i := range options {
+		i(opts)
+	}
+	return *opts
+}
+
+// appendInt append v padded with zero to width,
+// negative v has '-' before the padded absolute value (e.g. -0001).
+func appendInt(b []byte, v int64, width int) []byte {
+	var u = uint64(v)
+	if v < 0 {
+		b = append(b, '-')
+		u = -u
+	}
+	var buf [20]byte
+	var w = len(buf)
+	for u >= 10 {
+		w--
+		buf[w] = byte(u%10) + '0'
+		u /= 10
+	}
+	w--
+	buf[w] = byte(u) + '0'
+	for len(buf)-w < width {
+		w--
+		buf[w] = '0'
+	}
+	return append(b, buf[w:]...)
+}
+
+// appendFraction append decimal sign and fraction of v/size,
+// v must less than size.
+// It omits the decimal sign too when there is no digit.
+func (opts FormatOptions) appendFraction(b []byte, v, size uint64) []byte {
+	var start = len(b)
+	b = append(b, opts.decimalSign())
+	var end = len(b)
+	for i := 0; i < opts.MaxFractionDigits; i++ {
+		v *= 10
+		b = append(b, byte(v/size)+'0')
+		v %= size
+		if b[len(b)-1] != '0' || i < opts.MinFractionDigits {
+			end = len(b)
+		}
+	}
+	if end == start+1 {
+		return b[:start]
+	}
+	return b[:end]
+}
+
+// exactFraction reports whether v/size can be written in at most digits.
+func exactFraction(v, size uint64, digits int) bool {
+	for i := 0; i < digits && v != 0; i++ {
+		v = v * 10 % size
+	}
+	return v == 0
+}
+
+// durationLayout is components to write,
+// index is same as nominalSizes.
+type durationLayout struct {
+	values [7]int64
+	// nano is remainder in nanoseconds that written as fraction of values[last].
+	nano int64
+	last int
+}
+
+// mulDiv returns quotient and remainder of v*a/b, both have sign of v.
+// a and b must be positive.
+func mulDiv(v, a, b int64) (q, r int64, err error) {
+	var u = uint64(v)
+	if v < 0 {
+		u = -u
+	}
+	hi, lo := bits.Mul64(u, uint64(a))
+	if hi >= uint64(b) {
+		return 0, 0, ErrOverflow
+	}
+	uq, ur := bits.Div64(hi, lo, uint64(b))
+	if uq > uint64(maxInt64) {
+		return 0, 0, ErrOverflow
+	}
+	q, r = int64(uq), int64(ur)
+	if v < 0 {
+		q, r = -q, -r
+	}
+	return
+}
+
+// spread adds nano into components after index i, remainder goes to l.nano.
+func (l *durationLayout) spread(i int, nano int64) (err error) {
+	for i++; i < len(l.values); i++ {
+		var size = int64(nominalSizes[i])
+		l.values[i], err = addInt(l.values[i], nano/size)
+		if err != nil {
+			return
+		}
+		nano %= size
+	}
+	l.nano += nano
+	l.values[len(l.values)-1], err = addInt(l.values[len(l.values)-1], l.nano/int64(time.Second))
+	l.nano %= int64(time.Second)
+	return
+}
+
+// tail returns nanoseconds of components after index i.
+func (l durationLayout) tail(i int) (nano int64, err error) {
+	nano = l.nano
+	for i++; i < len(l.values); i++ {
+		var v int64
+		v, err = multiplyInt(l.values[i], int64(nominalSizes[i]))
+		if err == nil {
+			nano, err = addInt(nano, v)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// restrict converts components to units.
+func (l *durationLayout) restrict(units Unit) (err error) {
+	var smallest = -1
+	for i := range l.values {
+		if units.Has(Unit(1 << uint(i))) {
+			smallest = i
+		}
+	}
+	if smallest < 0 {
+		return
+	}
+	for i := 0; i < smallest; i++ {
+		if units.Has(Unit(1<<uint(i))) || l.values[i] == 0 {
+			continue
+		}
+		var q, r int64
+		q, r, err = mulDiv(l.values[i], int64(nominalSizes[i]), int64(nominalSizes[i+1]))
+		if err != nil {
+			return
+		}
+		l.values[i] = 0
+		l.values[i+1], err = addInt(l.values[i+1], q)
+		if err != nil {
+			return
+		}
+		err = l.spread(i+1, r)
+		if err != nil {
+			return
+		}
+	}
+	var nano int64
+	nano, err = l.tail(smallest)
+	if err != nil {
+		return
+	}
+	var size = int64(nominalSizes[smallest])
+	l.values[smallest], err = addInt(l.values[smallest], nano/size)
+	if err != nil {
+		return
+	}
+	for i := smallest + 1; i < len(l.values); i++ {
+		l.values[i] = 0
+	}
+	l.nano = nano % size
+	l.last = smallest
+	return
+}
+
+// isSpread reports whether components after index i are same as
+// nano spread by ParseDuration (see spreadNano),
+// so fraction of values[i] parses back to them.
+func (l durationLayout) isSpread(i int, nano int64) bool {
+	for i++; i <= l.last; i++ {
+		var size = int64(nominalSizes[i])
+		if l.values[i] != nano/size {
+			return false
+		}
+		nano %= size
+	}
+	return nano == l.nano
+}
+
+// fold folds smaller components into fraction of larger component
+// while it is exact and parses back to same components.
+func (l *durationLayout) fold(digits int) {
+	for {
+		var emitted = l.last
+		for emitted >= 0 && l.values[emitted] == 0 && (emitted != l.last || l.nano == 0) {
+			emitted--
+		}
+		var j = emitted - 1
+		for j >= 0 && l.values[j] == 0 {
+			j--
+		}
+		if j < 0 {
+			return
+		}
+		var nano, err = l.tail(j)
+		if err != nil {
+			return
+		}
+		var size = int64(nominalSizes[j])
+		if nano <= -size || nano >= size ||
+			nano < 0 != (l.values[j] < 0) ||
+			!exactFraction(uint64(absInt(nano)), uint64(size), digits) ||
+			!l.isSpread(j, nano) {
+			return
+		}
+		for i := j + 1; i < len(l.values); i++ {
+			l.values[i] = 0
+		}
+		l.nano = nano
+		l.last = j
+	}
+}
+
+// newDurationLayout returns layout of d, or layout without unit conversion on overflow.
+func newDurationLayout(d Duration, opts FormatOptions) (l durationLayout) {
+	l = durationLayout{
+		values: [...]int64{d.Years, d.Months, d.Weeks, d.Days, d.Hours, d.Minutes, d.Seconds},
+		nano:   d.Nanoseconds,
+		last:   len(l.values) - 1,
+	}
+	if seconds, err := addInt(d.Seconds, d.Nanoseconds/int64(time.Second)); err == nil {
+		// keep fraction of seconds less than a second
+		l.values[6], l.nano = seconds, d.Nanoseconds%int64(time.Second)
+	}
+	if opts.Units != 0 {
+		var restricted = l
+		if restricted.restrict(opts.Units) != nil {
+			return
+		}
+		l = restricted
+	}
+	if opts.FoldFraction {
+		l.fold(opts.MaxFractionDigits)
+	}
+	return
+}
+
+// zeroUnit returns index of unit used for zero duration.
+func (opts FormatOptions) zeroUnit() int {
+	var u = opts.ZeroUnit
+	if u == 0 {
+		u = UnitDay
+	}
+	if opts.Units != 0 && !opts.Units.Has(u) {
+		for i := range nominalSizes[:7] {
+			if opts.Units.Has(Unit(1 << uint(i))) {
+				u = Unit(1 << uint(i))
+			}
+		}
+	}
+	return unitIndex(u)
+}
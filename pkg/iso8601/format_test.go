@@ -0,0 +1,156 @@
+package iso8601
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationAppendFormatOptions(t *testing.T) {
+	var hoursAndBelow = UnitHour | UnitMinute | UnitSecond
+	for _, c := range []struct {
+		duration Duration
+		options  []FormatOption
+		expected string
+		// whether parse expected should give same duration
+		roundTrip bool
+	}{
+		{duration: Duration{Seconds: 1, Nanoseconds: 5e8}, options: []FormatOption{FormatOptionFractionDigits(3)}, expected: "PT1.500S", roundTrip: true},
+		{duration: Duration{Seconds: 1}, options: []FormatOption{FormatOptionFractionDigits(3)}, expected: "PT1.000S", roundTrip: true},
+		{duration: Duration{Hours: 1}, options: []FormatOption{FormatOptionFractionDigits(3)}, expected: "PT1H", roundTrip: true},
+		{duration: Duration{Nanoseconds: 1}, options: []FormatOption{FormatOptionFractionDigits(3)}, expected: "PT0.000S"},
+		{duration: Duration{}, options: []FormatOption{FormatOptionFractionDigits(3)}, expected: "P0D", roundTrip: true},
+		{duration: Duration{Nanoseconds: 123456789}, options: []FormatOption{FormatOptionMaxFractionDigits(3)}, expected: "PT0.123S"},
+		{duration: Duration{Nanoseconds: -123456789}, options: []FormatOption{FormatOptionMaxFractionDigits(3)}, expected: "PT-0.123S"},
+		{duration: Duration{Nanoseconds: 1}, options: []FormatOption{FormatOptionMaxFractionDigits(3)}, expected: "P0D"},
+		{duration: Duration{Seconds: 1, Nanoseconds: 9e8}, options: []FormatOption{FormatOptionMaxFractionDigits(0)}, expected: "PT1S"},
+		{duration: Duration{Nanoseconds: 5e8}, options: []FormatOption{FormatOptionFractionDigits(3), FormatOptionMaxFractionDigits(2)}, expected: "PT0.50S", roundTrip: true},
+		{duration: Duration{Hours: 1, Minutes: 30}, options: []FormatOption{FormatOptionFoldFraction()}, expected: "PT1.5H", roundTrip: true},
+		{duration: Duration{Hours: 1, Minutes: 20}, options: []FormatOption{FormatOptionFoldFraction()}, expected: "PT1H20M", roundTrip: true},
+		{duration: Duration{Hours: 1, Minutes: 1, Seconds: 30}, options: []FormatOption{FormatOptionFoldFraction()}, expected: "PT1.025H", roundTrip: true},
+		{duration: Duration{Minutes: 30}, options: []FormatOption{FormatOptionFoldFraction()}, expected: "PT30M", roundTrip: true},
+		{duration: Duration{Hours: 1, Minutes: -30}, options: []FormatOption{FormatOptionFoldFraction()}, expected: "PT1H-30M", roundTrip: true},
+		{duration: Duration{Hours: -1, Minutes: -30}, options: []FormatOption{FormatOptionFoldFraction()}, expected: "PT-1.5H", roundTrip: true},
+		{duration: Duration{Hours: 1, Minutes: 90}, options: []FormatOption{FormatOptionFoldFraction()}, expected: "PT1H90M", roundTrip: true},
+		{duration: Duration{Days: 1, Hours: 12}, options: []FormatOption{FormatOptionFoldFraction()}, expected: "P1.5D", roundTrip: true},
+		{duration: Duration{Weeks: 1, Days: 3, Hours: 12}, options: []FormatOption{FormatOptionFoldFraction()}, expected: "P1.5W", roundTrip: true},
+		{duration: Duration{Years: 1, Months: 6}, options: []FormatOption{FormatOptionFoldFraction()}, expected: "P1.5Y", roundTrip: true},
+		{duration: Duration{Months: 1, Weeks: 2, Days: 1, Hours: 5, Minutes: 14, Seconds: 33}, options: []FormatOption{FormatOptionFoldFraction()}, expected: "P1.5M", roundTrip: true},
+		{duration: Duration{Hours: 1, Minutes: 30}, options: []FormatOption{FormatOptionFoldFraction(), FormatOptionComma()}, expected: "PT1,5H", roundTrip: true},
+		{duration: Duration{Days: 1, Hours: 1}, options: []FormatOption{FormatOptionUnits(hoursAndBelow)}, expected: "PT25H"},
+		{duration: Duration{Months: 1}, options: []FormatOption{FormatOptionUnits(hoursAndBelow)}, expected: "PT730H29M6S"},
+		{duration: Duration{Years: 1, Nanoseconds: 5e8}, options: []FormatOption{FormatOptionUnits(hoursAndBelow)}, expected: "PT8765H49M12.5S"},
+		{duration: Duration{Days: 1, Hours: -1}, options: []FormatOption{FormatOptionUnits(hoursAndBelow)}, expected: "PT23H"},
+		{duration: Duration{Minutes: 20}, options: []FormatOption{FormatOptionUnits(UnitHour)}, expected: "PT0.333333333H"},
+		{duration: Duration{Minutes: 20}, options: []FormatOption{FormatOptionUnits(UnitHour), FormatOptionMaxFractionDigits(3)}, expected: "PT0.333H"},
+		{duration: Duration{Hours: 36}, options: []FormatOption{FormatOptionUnits(UnitDay)}, expected: "P1.5D"},
+		{duration: Duration{Days: 1, Hours: -1}, options: []FormatOption{FormatOptionUnits(UnitDay)}, expected: "P0.958333333D"},
+		{duration: Duration{Hours: 1, Minutes: 30}, options: []FormatOption{FormatOptionUnits(UnitYear | UnitMinute)}, expected: "PT90M"},
+		{duration: Duration{Years: maxInt64}, options: []FormatOption{FormatOptionUnits(UnitSecond)}, expected: "P9223372036854775807Y"},
+		{duration: Duration{Days: 14}, options: []FormatOption{FormatOptionWeeks()}, expected: "P2W"},
+		{duration: Duration{Days: 10}, options: []FormatOption{FormatOptionWeeks()}, expected: "P1.428571428W"},
+		{duration: Duration{Years: 1}, options: []FormatOption{FormatOptionWeeks()}, expected: "P52.1775W"},
+		{duration: Duration{}, options: []FormatOption{FormatOptionWeeks()}, expected: "P0W"},
+		{duration: Duration{}, options: []FormatOption{FormatOptionZeroUnit(UnitSecond)}, expected: "PT0S"},
+		{duration: Duration{}, options: []FormatOption{FormatOptionZeroUnit(UnitHour)}, expected: "PT0H"},
+		{duration: Duration{Negative: true}, options: []FormatOption{FormatOptionUnits(hoursAndBelow)}, expected: "-PT0S"},
+		{duration: Duration{Nanoseconds: -5e8}, expected: "PT-0.5S", roundTrip: true},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			var v = string(c.duration.AppendFormat(nil, c.options...))
+			assert.Equal(t, c.expected, v)
+			if c.roundTrip {
+				d, err := ParseDuration(v)
+				require.NoError(t, err)
+				expected, err := c.duration.Normalize(NormalizeOptionCarry(0))
+				require.NoError(t, err)
+				actual, err := d.Normalize(NormalizeOptionCarry(0))
+				require.NoError(t, err)
+				assert.Equal(t, expected, actual)
+			}
+		})
+	}
+}
+
+func TestDurationFoldFractionRoundTrip(t *testing.T) {
+	var r = rand.New(rand.NewSource(1))
+	var component = func(max int64) int64 {
+		if r.Intn(2) == 0 {
+			return 0
+		}
+		return r.Int63n(2*max+1) - max
+	}
+	for n := 0; n < 20000; n++ {
+		var d = Duration{
+			Years:       component(3),
+			Months:      component(15),
+			Weeks:       component(6),
+			Days:        component(40),
+			Hours:       component(50),
+			Minutes:     component(90),
+			Seconds:     component(90),
+			Nanoseconds: component(1e9) / 1e8 * 1e8,
+			Negative:    r.Intn(4) == 0,
+		}
+		var v = string(d.AppendFormat(nil, FormatOptionFoldFraction()))
+		p, err := ParseDuration(v)
+		require.NoError(t, err, v)
+		expected, err := d.Normalize(NormalizeOptionCarry(0))
+		require.NoError(t, err)
+		actual, err := p.Normalize(NormalizeOptionCarry(0))
+		require.NoError(t, err)
+		require.Equal(t, expected, actual, "%s folded as %s", d, v)
+	}
+	for _, c := range []struct {
+		duration Duration
+		expected string
+	}{
+		{duration: Duration{Hours: -37, Seconds: -72}, expected: "PT-37H-72S"},
+		{duration: Duration{Days: 31, Seconds: 81}, expected: "P31DT81S"},
+	} {
+		assert.Equal(t, c.expected, string(c.duration.AppendFormat(nil, FormatOptionFoldFraction())))
+	}
+}
+
+func TestFormatOptionsAppendFraction(t *testing.T) {
+	for _, c := range []struct {
+		v, size  uint64
+		min, max int
+		expected string
+	}{
+		{v: 0, size: 10, max: 9, expected: ""},
+		{v: 5, size: 10, max: 9, expected: ".5"},
+		{v: 5, size: 10, min: 3, max: 9, expected: ".500"},
+		{v: 1, size: 3, max: 4, expected: ".3333"},
+		{v: 1, size: 3, max: 0, expected: ""},
+		{v: 0, size: 3, min: 2, max: 2, expected: ".00"},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			var opts = FormatOptions{MinFractionDigits: c.min, MaxFractionDigits: c.max}
+			assert.Equal(t, c.expected, string(opts.appendFraction(nil, c.v, c.size)))
+		})
+	}
+}
+
+func TestAppendInt(t *testing.T) {
+	for _, c := range []struct {
+		v        int64
+		width    int
+		expected string
+	}{
+		{v: 0, width: 4, expected: "0000"},
+		{v: 42, width: 2, expected: "42"},
+		{v: 2026, width: 2, expected: "2026"},
+		{v: 12345, width: 4, expected: "12345"},
+		{v: -1, width: 4, expected: "-0001"},
+		{v: -2026, width: 4, expected: "-2026"},
+		{v: maxInt64, width: 1, expected: "9223372036854775807"},
+		{v: minInt64, width: 1, expected: "-9223372036854775808"},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			assert.Equal(t, c.expected, string(appendInt(nil, c.v, c.width)))
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package iso8601
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+// ErrCalendarComponent returned when a duration with calendar component
+// (years, months, weeks or days) can not be represented.
+var ErrCalendarComponent = errors.New("iso8601: duration has calendar component")
+
+// DurationSyntax is syntax of a duration string.
+type DurationSyntax int
+
+const (
+	// DurationSyntaxUnknown means syntax is not detected.
+	DurationSyntaxUnknown DurationSyntax = iota
+	// DurationSyntaxISO8601 is iso8601 duration (e.g. PT1H30M).
+	DurationSyntaxISO8601
+	// DurationSyntaxGo is go duration used by time.ParseDuration (e.g. 1h30m).
+	DurationSyntaxGo
+)
+
+func (s DurationSyntax) String() string {
+	switch s {
+	case DurationSyntaxISO8601:
+		return "iso8601"
+	case DurationSyntaxGo:
+		return "go"
+	}
+	return "unknown"
+}
+
+// goSubsecondUnits are go duration units smaller than second.
+var goSubsecondUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond, // U+00B5 micro sign
+	"μs": time.Microsecond, // U+03BC greek letter mu
+	"ms": time.Millisecond,
+}
+
+// leadingGoUnit consumes the leading unit from s, unit ends at digit or '.'.
+func leadingGoUnit(s string) (unit, rem string) {
+	var i int
+	for i < len(s) && s[i] != '.' && (s[i] < '0' || s[i] > '9') {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// ParseGoDuration parse go duration string (e.g. 1h30m, see time.ParseDuration).
+// Components are kept as written instead of collapsed into nanoseconds,
+// hours, minutes and seconds are stored in their fields,
+// milliseconds, microseconds and nanoseconds are stored as Nanoseconds
+// and carried into Seconds when exceeds one second.
+// Fraction is spread into smaller components like ParseDuration.
+//
+// Returned error is ErrInvalidDuration.
+func ParseGoDuration(s string) (ret Duration, err error) {
+	orig := s
+	ret.Negative, s = leadingNegative(s)
+	if s == "0" {
+		return
+	}
+	if s == "" {
+		err = newErrInvalidDuration(orig, len(orig), 0, ReasonUnexpectedEnd)
+		return
+	}
+	for s != "" {
+		var start = len(orig) - len(s)
+		var v int64
+		v, s, err = leadingInt(s)
+		if err != nil {
+			err = newErrInvalidDuration(orig, start, componentLength(orig[start:]), ReasonOverflow)
+			return
+		}
+		var pre = len(orig)-len(s) != start
+		var digits string
+		if s != "" && s[0] == '.' {
+			s = s[1:]
+			digits = s[:leadingDigits(s)]
+			s = s[len(digits):]
+		}
+		if !pre && digits == "" {
+			err = newErrInvalidDuration(orig, start, len(orig)-len(s)-start, ReasonMissingDigits)
+			return
+		}
+		var unitOffset = len(orig) - len(s)
+		var unit string
+		unit, s = leadingGoUnit(s)
+		if unit == "" {
+			err = newErrInvalidDuration(orig, unitOffset, 0, ReasonMissingDesignator)
+			return
+		}
+		var overflow error
+		switch unit {
+		case "h":
+			ret.Hours, overflow = addInt(ret.Hours, v)
+			if overflow == nil {
+				overflow = spreadNano(&ret, 4, fractionOf(digits, time.Hour))
+			}
+		case "m":
+			ret.Minutes, overflow = addInt(ret.Minutes, v)
+			if overflow == nil {
+				overflow = spreadNano(&ret, 5, fractionOf(digits, time.Minute))
+			}
+		case "s":
+			ret.Seconds, overflow = addInt(ret.Seconds, v)
+			if overflow == nil {
+				ret.Nanoseconds += fractionOf(digits, time.Second)
+				overflow = carryNano(&ret)
+			}
+		default:
+			var size, ok = goSubsecondUnits[unit]
+			if !ok {
+				err = newErrInvalidDuration(orig, unitOffset, len(unit), ReasonUnknownDesignator)
+				return
+			}
+			var nano int64
+			nano, overflow = multiplyInt(v, int64(size))
+			if overflow == nil {
+				nano, overflow = addInt(nano, fractionOf(digits, size))
+			}
+			if overflow == nil {
+				ret.Nanoseconds, overflow = addInt(ret.Nanoseconds, nano)
+			}
+			if overflow == nil {
+				overflow = carryNano(&ret)
+			}
+		}
+		if overflow != nil {
+			err = newErrInvalidDuration(orig, start, len(orig)-len(s)-start, ReasonOverflow)
+			return
+		}
+	}
+	return
+}
+
+// ParseAnyDuration parse duration in iso8601 (e.g. PT1H30M) or go (e.g. 1h30m) syntax,
+// syntax is detected by designator 'P' after optional sign,
+// and returned even when parse failed.
+//
+// Returned error is ErrInvalidDuration.
+func ParseAnyDuration(s string, options ...ParseDurationOption) (ret Duration, syntax DurationSyntax, err error) {
+	var _, rem = leadingNegative(s)
+	if rem != "" && rem[0] == 'P' {
+		ret, err = ParseDuration(s, options...)
+		return ret, DurationSyntaxISO8601, err
+	}
+	ret, err = ParseGoDuration(s)
+	return ret, DurationSyntaxGo, err
+}
+
+// AppendGoFormat append d in go duration syntax (e.g. 1h30m) to b,
+// which can be parsed by time.ParseDuration.
+// Components are kept as is unless they have different sign.
+//
+// Returns ErrCalendarComponent when d has years, months, weeks or days,
+// and ErrOverflow when d is out of range of time.Duration.
+func (d Duration) AppendGoFormat(b []byte) ([]byte, error) {
+	if d.Years != 0 || d.Months != 0 || d.Weeks != 0 || d.Days != 0 {
+		return b, ErrCalendarComponent
+	}
+	var v, err = d.signed()
+	if err != nil {
+		return b, err
+	}
+	err = carryNano(&v)
+	if err != nil {
+		return b, err
+	}
+	var positive, negative bool
+	for _, i := range [...]int64{v.Hours, v.Minutes, v.Seconds, v.Nanoseconds} {
+		positive = positive || i > 0
+		negative = negative || i < 0
+	}
+	if positive && negative {
+		v, err = v.Normalize(NormalizeOptionCarry(CarrySeconds | CarryMinutes))
+		if err != nil {
+			return b, err
+		}
+		v, err = v.signed()
+		if err != nil {
+			return b, err
+		}
+		negative = v.Hours < 0 || v.Minutes < 0 || v.Seconds < 0 || v.Nanoseconds < 0
+	}
+	var h, m, s, nano = uint64(v.Hours), uint64(v.Minutes), uint64(v.Seconds), uint64(v.Nanoseconds)
+	if negative {
+		h, m, s, nano = -h, -m, -s, -nano
+	}
+	// time.ParseDuration accepts magnitude up to 1<<63 for negative value.
+	var limit uint64 = 1<<63 - 1
+	if negative {
+		limit++
+	}
+	var total uint64
+	for _, i := range [...][2]uint64{{h, uint64(time.Hour)}, {m, uint64(time.Minute)}, {s, uint64(time.Second)}, {nano, 1}} {
+		var hi, lo = bits.Mul64(i[0], i[1])
+		var carry uint64
+		total, carry = bits.Add64(total, lo, 0)
+		if hi != 0 || carry != 0 || total > limit {
+			return b, ErrOverflow
+		}
+	}
+	if negative {
+		b = append(b, '-')
+	}
+	if h != 0 {
+		b = strconv.AppendUint(b, h, 10)
+		b = append(b, 'h')
+	}
+	if m != 0 {
+		b = strconv.AppendUint(b, m, 10)
+		b = append(b, 'm')
+	}
+	if s != 0 || nano != 0 || h == 0 && m == 0 {
+		b = strconv.AppendUint(b, s, 10)
+		b = defaultFormatOptions.appendFraction(b, nano, uint64(time.Second))
+		b = append(b, 's')
+	}
+	return b, nil
+}
+
+// GoDurationString returns d in go duration syntax (e.g. 1h30m), see AppendGoFormat.
+func (d Duration) GoDurationString() (string, error) {
+	var b, err = d.AppendGoFormat(make([]byte, 0, 32))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
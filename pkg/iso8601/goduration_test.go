@@ -0,0 +1,126 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoDuration(t *testing.T) {
+	for _, c := range []struct {
+		s        string
+		expected Duration
+		err      error
+	}{
+		{s: "0", expected: Duration{}},
+		{s: "-0", expected: Duration{Negative: true}},
+		{s: "1h30m", expected: Duration{Hours: 1, Minutes: 30}},
+		{s: "90m", expected: Duration{Minutes: 90}},
+		{s: "1.5h", expected: Duration{Hours: 1, Minutes: 30}},
+		{s: "-1h", expected: Duration{Hours: 1, Negative: true}},
+		{s: "+1s", expected: Duration{Seconds: 1}},
+		{s: "1h1h", expected: Duration{Hours: 2}},
+		{s: "2h45m30.5s", expected: Duration{Hours: 2, Minutes: 45, Seconds: 30, Nanoseconds: 5e8}},
+		{s: ".5s", expected: Duration{Nanoseconds: 5e8}},
+		{s: "1500ms", expected: Duration{Seconds: 1, Nanoseconds: 5e8}},
+		{s: "1us", expected: Duration{Nanoseconds: 1e3}},
+		{s: "1µs", expected: Duration{Nanoseconds: 1e3}},
+		{s: "1μs", expected: Duration{Nanoseconds: 1e3}},
+		{s: "1.5us", expected: Duration{Nanoseconds: 1500}},
+		{s: "1s500ms", expected: Duration{Seconds: 1, Nanoseconds: 5e8}},
+		{s: "3ns", expected: Duration{Nanoseconds: 3}},
+		{s: "", err: ErrInvalidDuration{String: "", Offset: 0, Token: "", Reason: ReasonUnexpectedEnd}},
+		{s: "-", expected: Duration{Negative: true}, err: ErrInvalidDuration{String: "-", Offset: 1, Token: "", Reason: ReasonUnexpectedEnd}},
+		{s: "1", err: ErrInvalidDuration{String: "1", Offset: 1, Token: "", Reason: ReasonMissingDesignator}},
+		{s: "1x", err: ErrInvalidDuration{String: "1x", Offset: 1, Token: "x", Reason: ReasonUnknownDesignator}},
+		{s: "1h2d", expected: Duration{Hours: 1}, err: ErrInvalidDuration{String: "1h2d", Offset: 3, Token: "d", Reason: ReasonUnknownDesignator}},
+		{s: "h", err: ErrInvalidDuration{String: "h", Offset: 0, Token: "", Reason: ReasonMissingDigits}},
+		{s: "PT1H", err: ErrInvalidDuration{String: "PT1H", Offset: 0, Token: "", Reason: ReasonMissingDigits}},
+		{s: "9223372036854775808h", err: ErrInvalidDuration{String: "9223372036854775808h", Offset: 0, Token: "9223372036854775808h", Reason: ReasonOverflow}},
+		{s: "9223372036854775807ms", err: ErrInvalidDuration{String: "9223372036854775807ms", Offset: 0, Token: "9223372036854775807ms", Reason: ReasonOverflow}},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseGoDuration(c.s)
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+			if err == nil {
+				expected, err := time.ParseDuration(c.s)
+				require.NoError(t, err)
+				assert.Equal(t, expected, v.MustTimeDuration())
+			}
+		})
+	}
+}
+
+func TestParseAnyDuration(t *testing.T) {
+	for _, c := range []struct {
+		s        string
+		expected Duration
+		syntax   DurationSyntax
+		err      bool
+	}{
+		{s: "PT1H", expected: Duration{Hours: 1}, syntax: DurationSyntaxISO8601},
+		{s: "-PT1H", expected: Duration{Hours: 1, Negative: true}, syntax: DurationSyntaxISO8601},
+		{s: "1h", expected: Duration{Hours: 1}, syntax: DurationSyntaxGo},
+		{s: "-1h", expected: Duration{Hours: 1, Negative: true}, syntax: DurationSyntaxGo},
+		{s: "P1X", syntax: DurationSyntaxISO8601, err: true},
+		{s: "x", syntax: DurationSyntaxGo, err: true},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, syntax, err := ParseAnyDuration(c.s)
+			assert.Equal(t, c.syntax, syntax)
+			if c.err {
+				assert.IsType(t, ErrInvalidDuration{}, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+	assert.Equal(t, "iso8601", DurationSyntaxISO8601.String())
+	assert.Equal(t, "go", DurationSyntaxGo.String())
+	assert.Equal(t, "unknown", DurationSyntaxUnknown.String())
+}
+
+func TestDurationGoDurationString(t *testing.T) {
+	for _, c := range []struct {
+		duration Duration
+		expected string
+		err      error
+	}{
+		{duration: Duration{}, expected: "0s"},
+		{duration: Duration{Hours: 1, Minutes: 30}, expected: "1h30m"},
+		{duration: Duration{Minutes: 90}, expected: "90m"},
+		{duration: Duration{Nanoseconds: 5e8}, expected: "0.5s"},
+		{duration: Duration{Seconds: 1, Nanoseconds: 1}, expected: "1.000000001s"},
+		{duration: Duration{Hours: 1, Negative: true}, expected: "-1h"},
+		{duration: Duration{Hours: -1}, expected: "-1h"},
+		{duration: Duration{Hours: -1, Negative: true}, expected: "1h"},
+		{duration: Duration{Hours: 1, Minutes: -30}, expected: "30m"},
+		{duration: Duration{Hours: -1, Minutes: 30}, expected: "-30m"},
+		{duration: Duration{Hours: 100000}, expected: "100000h"},
+		{duration: Duration{Days: 1}, err: ErrCalendarComponent},
+		{duration: Duration{Years: 1}, err: ErrCalendarComponent},
+		{duration: Duration{Nanoseconds: maxInt64}, expected: "9223372036.854775807s"},
+		{duration: Duration{Nanoseconds: minInt64}, expected: "-9223372036.854775808s"},
+		{duration: Duration{Hours: 2562047, Minutes: 47, Seconds: 16, Nanoseconds: 854775807}, expected: "2562047h47m16.854775807s"},
+		{duration: Duration{Hours: -2562047, Minutes: -47, Seconds: -16, Nanoseconds: -854775808}, expected: "-2562047h47m16.854775808s"},
+		{duration: Duration{Hours: 2562047, Minutes: 47, Seconds: 16, Nanoseconds: 854775808}, err: ErrOverflow},
+		{duration: Duration{Hours: 2562048}, err: ErrOverflow},
+		{duration: Duration{Hours: maxInt64}, err: ErrOverflow},
+		{duration: Duration{Seconds: maxInt64, Negative: true}, err: ErrOverflow},
+	} {
+		t.Run(c.duration.String(), func(t *testing.T) {
+			v, err := c.duration.GoDurationString()
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+			if err == nil {
+				d, err := time.ParseDuration(v)
+				require.NoError(t, err)
+				assert.Equal(t, c.duration.MustTimeDuration(), d)
+			}
+		})
+	}
+}
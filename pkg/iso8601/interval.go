@@ -0,0 +1,413 @@
+package iso8601
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalidInterval returned when parse interval failed.
+type ErrInvalidInterval struct {
+	String string
+	// Offset is byte offset of Token in String.
+	Offset int
+	// Token is the offending part of String, may be empty (e.g. when string ended).
+	Token  string
+	Reason Reason
+}
+
+func newErrInvalidInterval(s string, offset, length int, reason Reason) ErrInvalidInterval {
+	return ErrInvalidInterval{
+		String: s,
+		Offset: offset,
+		Token:  s[offset : offset+length],
+		Reason: reason,
+	}
+}
+
+func (err ErrInvalidInterval) Error() string {
+	return parseErrorMessage("interval", err.String, err.Offset, err.Token, err.Reason)
+}
+
+// Unwrap returns ErrOverflow for ReasonOverflow,
+// so errors.Is(err, ErrOverflow) works.
+func (err ErrInvalidInterval) Unwrap() error {
+	if err.Reason == ReasonOverflow {
+		return ErrOverflow
+	}
+	return nil
+}
+
+// ErrMissingTime returned when resolve interval that only has duration.
+var ErrMissingTime = errors.New("iso8601: interval has no start or end time")
+
+// IntervalForm is which parts an interval has.
+type IntervalForm int
+
+const (
+	// IntervalFormAuto decide form by fields,
+	// zero Start or End means it is omitted.
+	IntervalFormAuto IntervalForm = iota
+	// IntervalFormStartEnd is start/end (2026-01-01T00:00:00Z/2026-02-01T00:00:00Z).
+	IntervalFormStartEnd
+	// IntervalFormStartDuration is start/duration (2026-01-01T00:00:00Z/P1M).
+	IntervalFormStartDuration
+	// IntervalFormDurationEnd is duration/end (P1M/2026-02-01T00:00:00Z).
+	IntervalFormDurationEnd
+	// IntervalFormDuration is duration only (P1M).
+	IntervalFormDuration
+)
+
+// Interval is iso8601 time interval.
+//
+// Fields used are decided by Form:
+//   - start/end: Start and End
+//   - start/duration: Start and Duration
+//   - duration/end: Duration and End
+//   - duration only: Duration
+type Interval struct {
+	Start    time.Time
+	End      time.Time
+	Duration Duration
+	// Form is set by ParseInterval,
+	// IntervalFormAuto (zero value) treats zero Start or End as omitted.
+	Form IntervalForm
+	// Separator is "--" when parsed from the alternative separator,
+	// any other value means '/'.
+	Separator string
+}
+
+// form returns Form, decided by fields for IntervalFormAuto.
+func (i Interval) form() IntervalForm {
+	if i.Form != IntervalFormAuto {
+		return i.Form
+	}
+	switch hasStart, hasEnd := !i.Start.IsZero(), !i.End.IsZero(); {
+	case hasStart && hasEnd:
+		return IntervalFormStartEnd
+	case hasStart:
+		return IntervalFormStartDuration
+	case hasEnd:
+		return IntervalFormDurationEnd
+	}
+	return IntervalFormDuration
+}
+
+// HasStart reports whether i has start time.
+func (i Interval) HasStart() bool {
+	var f = i.form()
+	return f == IntervalFormStartEnd || f == IntervalFormStartDuration
+}
+
+// HasEnd reports whether i has end time.
+func (i Interval) HasEnd() bool {
+	var f = i.form()
+	return f == IntervalFormStartEnd || f == IntervalFormDurationEnd
+}
+
+// newStartEnd returns interval in start/end form.
+func newStartEnd(start, end time.Time) Interval {
+	return Interval{Start: start, End: end, Form: IntervalFormStartEnd}
+}
+
+// intervalSeparator returns index and length of separator in s,
+// '/' is preferred and "--" is the alternative for file names.
+func intervalSeparator(s string) (index, length int) {
+	if index = strings.IndexByte(s, '/'); index >= 0 {
+		return index, 1
+	}
+	if index = strings.Index(s, "--"); index >= 0 {
+		return index, 2
+	}
+	return -1, 0
+}
+
+// isIntervalDuration reports whether part of interval is duration.
+func isIntervalDuration(s string) bool {
+	return s != "" && (s[0] == 'P' || s[0] == '+' || s[0] == '-')
+}
+
+// ParseInterval parse iso8601 time interval in
+// start/end, start/duration, duration/end or duration only form,
+// "--" can be used as separator instead of '/'.
+// Form and Separator of result are set, so it formats back to s.
+//
+// Times are parsed by ParseTime with options, durations are parsed by ParseDuration
+// and must not be negative.
+//
+// Returned error is ErrInvalidInterval.
+func ParseInterval(s string, options ...ParseTimeOption) (ret Interval, err error) {
+	var sep, sepLen = intervalSeparator(s)
+	if sep < 0 {
+		ret.Duration, err = parseIntervalDuration(s, 0, s)
+		if err != nil {
+			return Interval{}, err
+		}
+		ret.Form = IntervalFormDuration
+		return
+	}
+	var start, end = s[:sep], s[sep+sepLen:]
+	if sepLen == 2 {
+		ret.Separator = "--"
+	}
+	var startIsDuration, endIsDuration = isIntervalDuration(start), isIntervalDuration(end)
+	if startIsDuration && endIsDuration {
+		err = newErrInvalidInterval(s, sep+sepLen, len(end), ReasonMissingTime)
+		return
+	}
+	switch {
+	case startIsDuration:
+		ret.Form = IntervalFormDurationEnd
+	case endIsDuration:
+		ret.Form = IntervalFormStartDuration
+	default:
+		ret.Form = IntervalFormStartEnd
+	}
+	if startIsDuration {
+		ret.Duration, err = parseIntervalDuration(s, 0, start)
+	} else {
+		ret.Start, err = parseIntervalTime(s, 0, start, options)
+	}
+	if err != nil {
+		return Interval{}, err
+	}
+	if endIsDuration {
+		ret.Duration, err = parseIntervalDuration(s, sep+sepLen, end)
+	} else {
+		ret.End, err = parseIntervalTime(s, sep+sepLen, end, options)
+	}
+	if err != nil {
+		return Interval{}, err
+	}
+	return
+}
+
+// parseIntervalTime parse part of s at offset as time.
+func parseIntervalTime(s string, offset int, part string, options []ParseTimeOption) (time.Time, error) {
+	var v, err = ParseTime(part, options...)
+	if e, ok := err.(ErrInvalidTime); ok {
+		return v, newErrInvalidInterval(s, offset+e.Offset, len(e.Token), e.Reason)
+	}
+	return v, err
+}
+
+// parseIntervalDuration parse part of s at offset as non-negative duration.
+func parseIntervalDuration(s string, offset int, part string) (Duration, error) {
+	var v, err = ParseDuration(part)
+	if e, ok := err.(ErrInvalidDuration); ok {
+		return v, newErrInvalidInterval(s, offset+e.Offset, len(e.Token), e.Reason)
+	}
+	if v.Negative {
+		return Duration{}, newErrInvalidInterval(s, offset, 1, ReasonSign)
+	}
+	return v, err
+}
+
+// MustParseInterval execute ParseInterval and panic if error.
+func MustParseInterval(s string, options ...ParseTimeOption) Interval {
+	var ret, err = ParseInterval(s, options...)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// AppendFormat append i to b in its form with its separator,
+// options are used for both FormatTime and Duration.AppendFormat.
+func (i Interval) AppendFormat(b []byte, options ...FormatOption) []byte {
+	var hasStart, hasEnd = i.HasStart(), i.HasEnd()
+	if hasStart {
+		b = append(b, FormatTime(i.Start, options...)...)
+	} else {
+		b = i.Duration.AppendFormat(b, options...)
+	}
+	if !hasStart && !hasEnd {
+		return b
+	}
+	if i.Separator == "--" {
+		b = append(b, "--"...)
+	} else {
+		b = append(b, '/')
+	}
+	if hasEnd {
+		b = append(b, FormatTime(i.End, options...)...)
+	} else {
+		b = i.Duration.AppendFormat(b, options...)
+	}
+	return b
+}
+
+func (i Interval) String() string {
+	return string(i.AppendFormat(make([]byte, 0, 64)))
+}
+
+// Resolve returns concrete start and end time,
+// start or end is computed from the other one with Duration.AddTo when omitted.
+// Returns ErrMissingTime for duration only form.
+func (i Interval) Resolve(options ...AddOption) (start, end time.Time, err error) {
+	start, end = i.Start, i.End
+	switch i.form() {
+	case IntervalFormStartEnd:
+	case IntervalFormStartDuration:
+		end, err = i.Duration.AddTo(start, options...)
+	case IntervalFormDurationEnd:
+		start, err = i.Duration.Neg().AddTo(end, options...)
+	default:
+		err = ErrMissingTime
+	}
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (i Interval) MarshalText() ([]byte, error) {
+	return i.AppendFormat(make([]byte, 0, 64)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *Interval) UnmarshalText(data []byte) error {
+	var v, err = ParseInterval(string(data))
+	if err != nil {
+		return err
+	}
+	*i = v
+	return nil
+}
+
+// Resolved returns interval in start/end form, see Resolve.
+func (i Interval) Resolved(options ...AddOption) (Interval, error) {
+	var start, end, err = i.Resolve(options...)
+	if err != nil {
+		return Interval{}, err
+	}
+	return newStartEnd(start, end), nil
+}
+
+// Interval algebra below use half-open [start, end) semantics on resolved interval,
+// interval that can not be resolved (e.g. duration only) is treated as empty.
+// Use Resolved for options of Duration.AddTo.
+//
+// Results are in start/end form.
+
+// bounds returns resolved start and end, ok is false for empty interval.
+func (i Interval) bounds() (start, end time.Time, ok bool) {
+	var err error
+	start, end, err = i.Resolve()
+	return start, end, err == nil && start.Before(end)
+}
+
+// IsEmpty reports whether i contains no instant.
+func (i Interval) IsEmpty() bool {
+	var _, _, ok = i.bounds()
+	return !ok
+}
+
+// Contains reports whether t is in [start, end).
+func (i Interval) Contains(t time.Time) bool {
+	var start, end, ok = i.bounds()
+	return ok && !t.Before(start) && t.Before(end)
+}
+
+// ContainsInterval reports whether every instant of o is in i,
+// empty interval is contained by any interval.
+func (i Interval) ContainsInterval(o Interval) bool {
+	var oStart, oEnd, oOK = o.bounds()
+	if !oOK {
+		return true
+	}
+	var start, end, ok = i.bounds()
+	return ok && !oStart.Before(start) && !oEnd.After(end)
+}
+
+// Overlaps reports whether i and o have common instant,
+// adjacent intervals (e.g. [1, 2) and [2, 3)) do not overlap.
+func (i Interval) Overlaps(o Interval) bool {
+	var _, ok = i.Intersection(o)
+	return ok
+}
+
+// Intersection returns common part of i and o,
+// ok is false when they do not overlap.
+func (i Interval) Intersection(o Interval) (ret Interval, ok bool) {
+	var start, end, iOK = i.bounds()
+	var oStart, oEnd, oOK = o.bounds()
+	if !iOK || !oOK {
+		return
+	}
+	if oStart.After(start) {
+		start = oStart
+	}
+	if oEnd.Before(end) {
+		end = oEnd
+	}
+	if !start.Before(end) {
+		return
+	}
+	return newStartEnd(start, end), true
+}
+
+// Union returns interval that covers both i and o,
+// ok is false when they neither overlap nor are adjacent.
+// When one of them is empty, the other one is returned.
+func (i Interval) Union(o Interval) (ret Interval, ok bool) {
+	var start, end, iOK = i.bounds()
+	var oStart, oEnd, oOK = o.bounds()
+	switch {
+	case !iOK && !oOK:
+		return
+	case !iOK:
+		return newStartEnd(oStart, oEnd), true
+	case !oOK:
+		return newStartEnd(start, end), true
+	}
+	if start.After(oEnd) || oStart.After(end) {
+		return
+	}
+	if oStart.Before(start) {
+		start = oStart
+	}
+	if oEnd.After(end) {
+		end = oEnd
+	}
+	return newStartEnd(start, end), true
+}
+
+// Gap returns interval between i and o,
+// ok is false when they overlap, are adjacent or one of them is empty.
+func (i Interval) Gap(o Interval) (ret Interval, ok bool) {
+	var start, end, iOK = i.bounds()
+	var oStart, oEnd, oOK = o.bounds()
+	if !iOK || !oOK {
+		return
+	}
+	switch {
+	case end.Before(oStart):
+		return newStartEnd(end, oStart), true
+	case oEnd.Before(start):
+		return newStartEnd(oEnd, start), true
+	}
+	return
+}
+
+// Subtract returns parts of i that not in o, in chronological order,
+// it has 0 to 2 intervals.
+func (i Interval) Subtract(o Interval) []Interval {
+	var start, end, ok = i.bounds()
+	if !ok {
+		return nil
+	}
+	var oStart, oEnd, oOK = o.bounds()
+	if !oOK || !oStart.Before(end) || !start.Before(oEnd) {
+		return []Interval{newStartEnd(start, end)}
+	}
+	var ret = make([]Interval, 0, 2)
+	if start.Before(oStart) {
+		ret = append(ret, newStartEnd(start, oStart))
+	}
+	if oEnd.Before(end) {
+		ret = append(ret, newStartEnd(oEnd, end))
+	}
+	return ret
+}
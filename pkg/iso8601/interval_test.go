@@ -0,0 +1,183 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	var jan = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var feb = time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	for _, c := range []struct {
+		s         string
+		expected  Interval
+		formatted string
+	}{
+		{s: "2026-01-01T00:00Z/2026-02-01T00:00Z", expected: Interval{Start: jan, End: feb, Form: IntervalFormStartEnd}, formatted: "2026-01-01T00:00:00Z/2026-02-01T00:00:00Z"},
+		{s: "2026-01-01T00:00Z/P1M", expected: Interval{Start: jan, Duration: Duration{Months: 1}, Form: IntervalFormStartDuration}, formatted: "2026-01-01T00:00:00Z/P1M"},
+		{s: "P1M/2026-02-01T00:00Z", expected: Interval{End: feb, Duration: Duration{Months: 1}, Form: IntervalFormDurationEnd}, formatted: "P1M/2026-02-01T00:00:00Z"},
+		{s: "P1M", expected: Interval{Duration: Duration{Months: 1}, Form: IntervalFormDuration}, formatted: "P1M"},
+		{s: "20260101T0000Z--20260201T0000Z", expected: Interval{Start: jan, End: feb, Form: IntervalFormStartEnd, Separator: "--"}, formatted: "2026-01-01T00:00:00Z--2026-02-01T00:00:00Z"},
+		{s: "2026-01-01--P1M", expected: Interval{Start: jan, Duration: Duration{Months: 1}, Form: IntervalFormStartDuration, Separator: "--"}, formatted: "2026-01-01T00:00:00Z--P1M"},
+		{s: "0001-01-01T00:00Z/P1D", expected: Interval{Duration: Duration{Days: 1}, Form: IntervalFormStartDuration}, formatted: "0001-01-01T00:00:00Z/P1D"},
+		{s: "P1D/0001-01-01T00:00Z", expected: Interval{Duration: Duration{Days: 1}, Form: IntervalFormDurationEnd}, formatted: "P1D/0001-01-01T00:00:00Z"},
+		{s: "P1M--2026-02-01T00:00-05", expected: Interval{End: time.Date(2026, 2, 1, 0, 0, 0, 0, time.FixedZone("", -5*3600)), Duration: Duration{Months: 1}, Form: IntervalFormDurationEnd, Separator: "--"}, formatted: "P1M--2026-02-01T00:00:00-05:00"},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseInterval(c.s)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, v)
+			assert.Equal(t, c.formatted, v.String())
+			v2, err := ParseInterval(v.String())
+			require.NoError(t, err)
+			assert.Equal(t, v, v2)
+		})
+	}
+}
+
+func TestParseIntervalError(t *testing.T) {
+	for _, c := range []struct {
+		s   string
+		err error
+	}{
+		{s: "", err: ErrInvalidInterval{String: "", Offset: 0, Token: "", Reason: ReasonMissingDesignator}},
+		{s: "P1M/P1D", err: ErrInvalidInterval{String: "P1M/P1D", Offset: 4, Token: "P1D", Reason: ReasonMissingTime}},
+		{s: "2026-01-01/2026-13-01", err: ErrInvalidInterval{String: "2026-01-01/2026-13-01", Offset: 16, Token: "13", Reason: ReasonOutOfRange}},
+		{s: "2026-01-01/P1X", err: ErrInvalidInterval{String: "2026-01-01/P1X", Offset: 13, Token: "X", Reason: ReasonUnknownDesignator}},
+		{s: "2026-01-01/-P1D", err: ErrInvalidInterval{String: "2026-01-01/-P1D", Offset: 11, Token: "-", Reason: ReasonSign}},
+		{s: "-P1D", err: ErrInvalidInterval{String: "-P1D", Offset: 0, Token: "-", Reason: ReasonSign}},
+		{s: "2026-01-01/", err: ErrInvalidInterval{String: "2026-01-01/", Offset: 11, Token: "", Reason: ReasonUnexpectedEnd}},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseInterval(c.s)
+			assert.Equal(t, c.err, err)
+			assert.Equal(t, Interval{}, v)
+		})
+	}
+	_, err := ParseInterval("2026-01-01/PT99999999999999999999H")
+	assert.True(t, errors.Is(err, ErrOverflow))
+	assert.EqualError(t, ErrInvalidInterval{String: "P1M/P1D", Offset: 4, Token: "P1D", Reason: ReasonMissingTime}, `iso8601: invalid interval P1M/P1D: missing time "P1D" at offset 4`)
+}
+
+func TestIntervalResolve(t *testing.T) {
+	for _, c := range []struct {
+		s     string
+		start time.Time
+		end   time.Time
+		err   error
+	}{
+		{s: "2026-01-01/2026-02-01", start: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), end: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{s: "2026-01-31/P1M", start: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), end: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{s: "2024-02-29/P1Y", start: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), end: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{s: "P1M/2026-03-01", start: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), end: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{s: "P1DT1H/2026-03-01", start: time.Date(2026, 2, 27, 23, 0, 0, 0, time.UTC), end: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{s: "P1M", err: ErrMissingTime},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			start, end, err := MustParseInterval(c.s).Resolve()
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.start, start)
+			assert.Equal(t, c.end, end)
+		})
+	}
+	start, end, err := MustParseInterval("2026-01-31/P1M").Resolve(AddOptionMonthEnd(MonthEndClamp))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), end)
+}
+
+func TestIntervalText(t *testing.T) {
+	var v struct {
+		Interval Interval
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"Interval":"2026-01-01T00:00Z/P1M"}`), &v))
+	assert.Equal(t, Interval{Start: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Duration: Duration{Months: 1}, Form: IntervalFormStartDuration}, v.Interval)
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"Interval":"2026-01-01T00:00:00Z/P1M"}`, string(data))
+	assert.Error(t, json.Unmarshal([]byte(`{"Interval":"P1M/P1D"}`), &v))
+}
+
+func TestIntervalAlgebra(t *testing.T) {
+	var day = func(d int) time.Time {
+		return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	var iv = func(start, end int) Interval {
+		return Interval{Start: day(start), End: day(end), Form: IntervalFormStartEnd}
+	}
+	var empty = Interval{Duration: Duration{Days: 1}}
+
+	t.Run("IsEmpty", func(t *testing.T) {
+		assert.False(t, iv(1, 2).IsEmpty())
+		assert.True(t, iv(2, 2).IsEmpty())
+		assert.True(t, iv(3, 2).IsEmpty())
+		assert.True(t, empty.IsEmpty())
+		assert.False(t, Interval{Start: day(1), Duration: Duration{Days: 1}}.IsEmpty())
+	})
+	t.Run("Contains", func(t *testing.T) {
+		assert.True(t, iv(1, 3).Contains(day(1)))
+		assert.True(t, iv(1, 3).Contains(day(2)))
+		assert.False(t, iv(1, 3).Contains(day(3)))
+		assert.False(t, iv(1, 3).Contains(day(3).Add(time.Nanosecond)))
+		assert.True(t, MustParseInterval("P1D/2026-01-03").Contains(day(2)))
+		assert.False(t, empty.Contains(day(1)))
+	})
+	t.Run("ContainsInterval", func(t *testing.T) {
+		assert.True(t, iv(1, 5).ContainsInterval(iv(1, 5)))
+		assert.True(t, iv(1, 5).ContainsInterval(iv(2, 3)))
+		assert.False(t, iv(1, 5).ContainsInterval(iv(4, 6)))
+		assert.True(t, iv(1, 5).ContainsInterval(empty))
+		assert.False(t, empty.ContainsInterval(iv(1, 2)))
+	})
+	t.Run("Overlaps", func(t *testing.T) {
+		assert.True(t, iv(1, 3).Overlaps(iv(2, 4)))
+		assert.False(t, iv(1, 3).Overlaps(iv(3, 4)))
+		assert.False(t, iv(3, 4).Overlaps(iv(1, 3)))
+		assert.False(t, iv(1, 3).Overlaps(empty))
+	})
+	for _, c := range []struct {
+		name         string
+		a, b         Interval
+		intersection Interval
+		union        Interval
+		gap          Interval
+		subtract     []Interval
+	}{
+		{name: "overlap", a: iv(1, 4), b: iv(3, 6), intersection: iv(3, 4), union: iv(1, 6), subtract: []Interval{iv(1, 3)}},
+		{name: "adjacent", a: iv(1, 3), b: iv(3, 6), union: iv(1, 6), subtract: []Interval{iv(1, 3)}},
+		{name: "disjoint", a: iv(5, 6), b: iv(1, 3), gap: iv(3, 5), subtract: []Interval{iv(5, 6)}},
+		{name: "inner", a: iv(1, 6), b: iv(2, 3), intersection: iv(2, 3), union: iv(1, 6), subtract: []Interval{iv(1, 2), iv(3, 6)}},
+		{name: "outer", a: iv(2, 3), b: iv(1, 6), intersection: iv(2, 3), union: iv(1, 6), subtract: []Interval{}},
+		{name: "empty", a: iv(1, 3), b: empty, union: iv(1, 3), subtract: []Interval{iv(1, 3)}},
+		{name: "from empty", a: empty, b: iv(1, 3), union: iv(1, 3)},
+		{name: "mixed form", a: MustParseInterval("2026-01-01/P3D"), b: MustParseInterval("P2D/2026-01-05"), intersection: iv(3, 4), union: iv(1, 5), subtract: []Interval{iv(1, 3)}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var v, ok = c.a.Intersection(c.b)
+			assert.Equal(t, c.intersection, v)
+			assert.Equal(t, c.intersection != Interval{}, ok)
+			v, ok = c.a.Union(c.b)
+			assert.Equal(t, c.union, v)
+			assert.Equal(t, c.union != Interval{}, ok)
+			v, ok = c.a.Gap(c.b)
+			assert.Equal(t, c.gap, v)
+			assert.Equal(t, c.gap != Interval{}, ok)
+			assert.Equal(t, c.subtract, c.a.Subtract(c.b))
+		})
+	}
+	v, _ := iv(1, 4).Intersection(MustParseInterval("2026-01-03/P1W"))
+	assert.Equal(t, "2026-01-03T00:00:00Z/2026-01-04T00:00:00Z", v.String())
+}
+
+func TestIntervalResolved(t *testing.T) {
+	v, err := MustParseInterval("2026-01-31/P1M").Resolved(AddOptionMonthEnd(MonthEndClamp))
+	require.NoError(t, err)
+	assert.Equal(t, Interval{Start: time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), Form: IntervalFormStartEnd}, v)
+	_, err = MustParseInterval("P1M").Resolved()
+	assert.Equal(t, ErrMissingTime, err)
+}
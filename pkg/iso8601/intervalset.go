@@ -0,0 +1,241 @@
+package iso8601
+
+import (
+	"sort"
+	"time"
+)
+
+// IntervalSet is a set of instants, represented by sorted intervals
+// in start/end form with half-open [start, end) semantics,
+// intervals are non-empty and neither overlap nor are adjacent.
+//
+// Zero value is empty set. IntervalSet is immutable, methods return new set.
+type IntervalSet struct {
+	intervals []Interval
+}
+
+// NewIntervalSet returns set of instants in any of intervals,
+// intervals are resolved by Interval.Resolve and merged,
+// empty or unresolvable interval is ignored.
+func NewIntervalSet(intervals ...Interval) IntervalSet {
+	var ret = make([]Interval, 0, len(intervals))
+	for _, i := range intervals {
+		var start, end, ok = i.bounds()
+		if ok {
+			ret = append(ret, newStartEnd(start, end))
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Start.Before(ret[j].Start)
+	})
+	var n = 0
+	for _, i := range ret {
+		if n > 0 && !i.Start.After(ret[n-1].End) {
+			if i.End.After(ret[n-1].End) {
+				ret[n-1].End = i.End
+			}
+			continue
+		}
+		ret[n] = i
+		n++
+	}
+	if n == 0 {
+		return IntervalSet{}
+	}
+	return IntervalSet{intervals: ret[:n]}
+}
+
+// Intervals returns copy of intervals in chronological order.
+func (s IntervalSet) Intervals() []Interval {
+	if len(s.intervals) == 0 {
+		return nil
+	}
+	var ret = make([]Interval, len(s.intervals))
+	copy(ret, s.intervals)
+	return ret
+}
+
+// Len returns count of intervals.
+func (s IntervalSet) Len() int {
+	return len(s.intervals)
+}
+
+// IsEmpty reports whether s contains no instant.
+func (s IntervalSet) IsEmpty() bool {
+	return len(s.intervals) == 0
+}
+
+// search returns index of first interval that ends after t.
+func (s IntervalSet) search(t time.Time) int {
+	return sort.Search(len(s.intervals), func(i int) bool {
+		return s.intervals[i].End.After(t)
+	})
+}
+
+// Contains reports whether t is in s, it takes logarithmic time.
+func (s IntervalSet) Contains(t time.Time) bool {
+	var index = s.search(t)
+	return index < len(s.intervals) && !t.Before(s.intervals[index].Start)
+}
+
+// ContainsInterval reports whether every instant of i is in s,
+// it takes logarithmic time.
+func (s IntervalSet) ContainsInterval(i Interval) bool {
+	var start, end, ok = i.bounds()
+	if !ok {
+		return true
+	}
+	var index = s.search(start)
+	return index < len(s.intervals) &&
+		!start.Before(s.intervals[index].Start) &&
+		!end.After(s.intervals[index].End)
+}
+
+// Add returns set of instants in s or any of intervals.
+func (s IntervalSet) Add(intervals ...Interval) IntervalSet {
+	return s.Union(NewIntervalSet(intervals...))
+}
+
+// Remove returns set of instants in s but not in any of intervals.
+func (s IntervalSet) Remove(intervals ...Interval) IntervalSet {
+	return s.Difference(NewIntervalSet(intervals...))
+}
+
+// Union returns set of instants in s or o.
+func (s IntervalSet) Union(o IntervalSet) IntervalSet {
+	var intervals = make([]Interval, 0, len(s.intervals)+len(o.intervals))
+	intervals = append(intervals, s.intervals...)
+	intervals = append(intervals, o.intervals...)
+	return NewIntervalSet(intervals...)
+}
+
+// Intersection returns set of instants in both s and o.
+func (s IntervalSet) Intersection(o IntervalSet) IntervalSet {
+	var ret []Interval
+	var i, j = 0, 0
+	for i < len(s.intervals) && j < len(o.intervals) {
+		var a, b = s.intervals[i], o.intervals[j]
+		if v, ok := a.Intersection(b); ok {
+			ret = append(ret, v)
+		}
+		if a.End.Before(b.End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return IntervalSet{intervals: ret}
+}
+
+// Difference returns set of instants in s but not in o.
+func (s IntervalSet) Difference(o IntervalSet) IntervalSet {
+	var ret []Interval
+	var j = 0
+	for _, a := range s.intervals {
+		for j < len(o.intervals) && !o.intervals[j].End.After(a.Start) {
+			j++
+		}
+		for k := j; k < len(o.intervals) && o.intervals[k].Start.Before(a.End); k++ {
+			var b = o.intervals[k]
+			if a.Start.Before(b.Start) {
+				ret = append(ret, newStartEnd(a.Start, b.Start))
+			}
+			a.Start = b.End
+		}
+		if a.Start.Before(a.End) {
+			ret = append(ret, a)
+		}
+	}
+	return IntervalSet{intervals: ret}
+}
+
+// Complement returns set of instants in within but not in s.
+func (s IntervalSet) Complement(within Interval) IntervalSet {
+	return NewIntervalSet(within).Difference(s)
+}
+
+// isSpace reports whether c is ignored around interval in text of IntervalSet.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r'
+}
+
+// ParseIntervalSet parse list of iso8601 time intervals
+// separated by ',' or newline, see ParseInterval.
+// ',' between digits is decimal sign (e.g. 2026-01-01T00:00:00,5Z/PT1H),
+// so add space after separator when interval ends with digit.
+// Spaces around interval and empty lines are ignored,
+// duration only interval is rejected.
+//
+// Returned error is ErrInvalidInterval.
+func ParseIntervalSet(s string, options ...ParseTimeOption) (ret IntervalSet, err error) {
+	var intervals []Interval
+	var start = 0
+	for index := 0; index <= len(s); index++ {
+		if index < len(s) {
+			var c = s[index]
+			if c != '\n' && c != ',' {
+				continue
+			}
+			if c == ',' && index > 0 && index+1 < len(s) &&
+				s[index-1] >= '0' && s[index-1] <= '9' &&
+				s[index+1] >= '0' && s[index+1] <= '9' {
+				continue
+			}
+		}
+		var itemStart, itemEnd = start, index
+		start = index + 1
+		for itemStart < itemEnd && isSpace(s[itemStart]) {
+			itemStart++
+		}
+		for itemEnd > itemStart && isSpace(s[itemEnd-1]) {
+			itemEnd--
+		}
+		if itemStart == itemEnd {
+			continue
+		}
+		var v Interval
+		v, err = ParseInterval(s[itemStart:itemEnd], options...)
+		if e, ok := err.(ErrInvalidInterval); ok {
+			return IntervalSet{}, newErrInvalidInterval(s, itemStart+e.Offset, len(e.Token), e.Reason)
+		}
+		if err != nil {
+			return IntervalSet{}, err
+		}
+		if !v.HasStart() && !v.HasEnd() {
+			return IntervalSet{}, newErrInvalidInterval(s, itemStart, itemEnd-itemStart, ReasonMissingTime)
+		}
+		intervals = append(intervals, v)
+	}
+	return NewIntervalSet(intervals...), nil
+}
+
+// AppendFormat append s to b as intervals separated by ", ",
+// options are used for Interval.AppendFormat.
+func (s IntervalSet) AppendFormat(b []byte, options ...FormatOption) []byte {
+	for index, i := range s.intervals {
+		if index > 0 {
+			b = append(b, ',', ' ')
+		}
+		b = i.AppendFormat(b, options...)
+	}
+	return b
+}
+
+func (s IntervalSet) String() string {
+	return string(s.AppendFormat(make([]byte, 0, 64*len(s.intervals))))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s IntervalSet) MarshalText() ([]byte, error) {
+	return s.AppendFormat(make([]byte, 0, 64*len(s.intervals))), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *IntervalSet) UnmarshalText(data []byte) error {
+	var v, err = ParseIntervalSet(string(data))
+	if err != nil {
+		return err
+	}
+	*s = v
+	return nil
+}
@@ -0,0 +1,140 @@
+package iso8601
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervalSet(t *testing.T) {
+	var day = func(d int) time.Time {
+		return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	var iv = func(start, end int) Interval {
+		return Interval{Start: day(start), End: day(end), Form: IntervalFormStartEnd}
+	}
+
+	t.Run("New", func(t *testing.T) {
+		assert.Equal(t, IntervalSet{}, NewIntervalSet())
+		assert.Equal(t, IntervalSet{}, NewIntervalSet(iv(2, 2), Interval{Duration: Duration{Days: 1}}))
+		var s = NewIntervalSet(iv(10, 12), iv(1, 3), iv(2, 4), iv(4, 5), iv(7, 8), iv(11, 11))
+		assert.Equal(t, []Interval{iv(1, 5), iv(7, 8), iv(10, 12)}, s.Intervals())
+		assert.Equal(t, 3, s.Len())
+		assert.False(t, s.IsEmpty())
+		assert.True(t, IntervalSet{}.IsEmpty())
+		assert.Nil(t, IntervalSet{}.Intervals())
+		assert.Equal(t, []Interval{iv(1, 4)}, NewIntervalSet(MustParseInterval("2026-01-01/P3D")).Intervals())
+	})
+
+	var s = NewIntervalSet(iv(1, 5), iv(7, 8), iv(10, 12))
+	t.Run("Contains", func(t *testing.T) {
+		for _, c := range []struct {
+			t        time.Time
+			expected bool
+		}{
+			{t: day(1).Add(-1), expected: false},
+			{t: day(1), expected: true},
+			{t: day(4), expected: true},
+			{t: day(5), expected: false},
+			{t: day(6), expected: false},
+			{t: day(7), expected: true},
+			{t: day(11), expected: true},
+			{t: day(12), expected: false},
+		} {
+			assert.Equal(t, c.expected, s.Contains(c.t), c.t)
+		}
+		assert.False(t, IntervalSet{}.Contains(day(1)))
+		assert.True(t, s.ContainsInterval(iv(2, 5)))
+		assert.True(t, s.ContainsInterval(iv(7, 8)))
+		assert.False(t, s.ContainsInterval(iv(4, 7)))
+		assert.False(t, s.ContainsInterval(iv(5, 6)))
+		assert.True(t, s.ContainsInterval(iv(6, 6)))
+	})
+	t.Run("Add", func(t *testing.T) {
+		assert.Equal(t, []Interval{iv(1, 8), iv(10, 12)}, s.Add(iv(5, 7)).Intervals())
+		assert.Equal(t, []Interval{iv(1, 5), iv(7, 8), iv(10, 12)}, s.Intervals())
+	})
+	t.Run("Remove", func(t *testing.T) {
+		assert.Equal(t, []Interval{iv(1, 2), iv(3, 5), iv(11, 12)}, s.Remove(iv(2, 3), iv(6, 11)).Intervals())
+		assert.Equal(t, []Interval(nil), s.Remove(iv(1, 12)).Intervals())
+	})
+	t.Run("Intersection", func(t *testing.T) {
+		var o = NewIntervalSet(iv(2, 3), iv(4, 11))
+		assert.Equal(t, []Interval{iv(2, 3), iv(4, 5), iv(7, 8), iv(10, 11)}, s.Intersection(o).Intervals())
+		assert.Equal(t, s.Intersection(o), o.Intersection(s))
+		assert.True(t, s.Intersection(IntervalSet{}).IsEmpty())
+	})
+	t.Run("Union", func(t *testing.T) {
+		var o = NewIntervalSet(iv(5, 6), iv(8, 10))
+		assert.Equal(t, []Interval{iv(1, 6), iv(7, 12)}, s.Union(o).Intervals())
+	})
+	t.Run("Difference", func(t *testing.T) {
+		var o = NewIntervalSet(iv(0, 2), iv(3, 4), iv(7, 8), iv(11, 20))
+		assert.Equal(t, []Interval{iv(2, 3), iv(4, 5), iv(10, 11)}, s.Difference(o).Intervals())
+		assert.Equal(t, s, s.Difference(IntervalSet{}))
+	})
+	t.Run("Complement", func(t *testing.T) {
+		assert.Equal(t, []Interval{iv(0, 1), iv(5, 7), iv(8, 10), iv(12, 20)}, s.Complement(iv(0, 20)).Intervals())
+		assert.Equal(t, []Interval{iv(5, 7), iv(8, 9)}, s.Complement(iv(3, 9)).Intervals())
+	})
+}
+
+func TestParseIntervalSet(t *testing.T) {
+	var day = func(d int) time.Time {
+		return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	for _, c := range []struct {
+		s        string
+		expected []Interval
+	}{
+		{s: ""},
+		{s: " \n"},
+		{s: "2026-01-01T00:00Z/P1D", expected: []Interval{{Start: day(1), End: day(2), Form: IntervalFormStartEnd}}},
+		{s: "2026-01-05T00:00Z/P1D,2026-01-01T00:00Z/P1D", expected: []Interval{{Start: day(1), End: day(2), Form: IntervalFormStartEnd}, {Start: day(5), End: day(6), Form: IntervalFormStartEnd}}},
+		{s: "2026-01-01T00:00:00,5Z/P1D, 2026-01-05T00:00Z/2026-01-06T00:00Z", expected: []Interval{
+			{Start: day(1).Add(5e8), End: day(2).Add(5e8), Form: IntervalFormStartEnd},
+			{Start: day(5), End: day(6), Form: IntervalFormStartEnd},
+		}},
+		{s: "2026-01-01/P1D\r\n\n  P1D/2026-01-05\n", expected: []Interval{{Start: day(1), End: day(2), Form: IntervalFormStartEnd}, {Start: day(4), End: day(5), Form: IntervalFormStartEnd}}},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseIntervalSet(c.s)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, v.Intervals())
+		})
+	}
+
+	_, err := ParseIntervalSet("2026-01-01/P1D,\n 2026-01-01/P1X")
+	assert.Equal(t, ErrInvalidInterval{String: "2026-01-01/P1D,\n 2026-01-01/P1X", Offset: 30, Token: "X", Reason: ReasonUnknownDesignator}, err)
+	_, err = ParseIntervalSet("2026-01-01/P1D, P1D ")
+	assert.Equal(t, ErrInvalidInterval{String: "2026-01-01/P1D, P1D ", Offset: 16, Token: "P1D", Reason: ReasonMissingTime}, err)
+}
+
+func TestIntervalSetText(t *testing.T) {
+	var s = NewIntervalSet(
+		MustParseInterval("2026-01-01T00:00Z/PT1H"),
+		MustParseInterval("2026-01-01T00:00+01:00/PT1H"),
+		MustParseInterval("2026-01-02T00:00Z/PT1H"),
+	)
+	assert.Equal(t, "2026-01-01T00:00:00+01:00/2026-01-01T01:00:00Z, 2026-01-02T00:00:00Z/2026-01-02T01:00:00Z", s.String())
+	assert.Equal(t, "", IntervalSet{}.String())
+
+	var v struct {
+		Set IntervalSet
+	}
+	v.Set = s
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"Set":"2026-01-01T00:00:00+01:00/2026-01-01T01:00:00Z, 2026-01-02T00:00:00Z/2026-01-02T01:00:00Z"}`, string(data))
+	v.Set = IntervalSet{}
+	require.NoError(t, json.Unmarshal(data, &v))
+	require.Equal(t, len(s.intervals), v.Set.Len())
+	for index, i := range v.Set.Intervals() {
+		assert.True(t, s.intervals[index].Start.Equal(i.Start))
+		assert.True(t, s.intervals[index].End.Equal(i.End))
+	}
+	assert.Error(t, json.Unmarshal([]byte(`{"Set":"P1D"}`), &v))
+}
@@ -0,0 +1,142 @@
+package iso8601
+
+import "time"
+
+// Carry is a set of carries that Duration.Normalize allowed to use,
+// can be combined with bitwise or.
+type Carry uint8
+
+const (
+	// CarrySeconds carry 60 seconds into 1 minute.
+	CarrySeconds Carry = 1 << iota
+	// CarryMinutes carry 60 minutes into 1 hour.
+	CarryMinutes
+	// CarryHours carry 24 hours into 1 day.
+	// It assumes nominal day that always has 24 hours,
+	// which is not true when DST changes.
+	CarryHours
+	// CarryDays carry 7 days into 1 week.
+	CarryDays
+	// CarryMonths carry 12 months into 1 year.
+	CarryMonths
+)
+
+// Has reports whether c contains every carry in v.
+func (c Carry) Has(v Carry) bool {
+	return c&v == v
+}
+
+// NormalizeOptions for Duration.Normalize
+type NormalizeOptions struct {
+	Carry Carry
+}
+
+// NormalizeOption mutate NormalizeOptions
+type NormalizeOption func(opts *NormalizeOptions)
+
+// NormalizeOptionCarry set allowed carries,
+// defaults to CarrySeconds | CarryMinutes | CarryMonths.
+// nanoseconds are always carried into seconds.
+func NormalizeOptionCarry(carry Carry) NormalizeOption {
+	return func(opts *NormalizeOptions) {
+		opts.Carry = carry
+	}
+}
+
+// NormalizeOptionNominalDay allow carry 24 hours into 1 day and 7 days into 1 week,
+// caller should accept that day not always has 24 hours.
+func NormalizeOptionNominalDay() NormalizeOption {
+	return func(opts *NormalizeOptions) {
+		opts.Carry |= CarryHours | CarryDays
+	}
+}
+
+// normalizeChain carry values ordered from smallest unit to largest unit,
+// ratios[i] is how many values[i] equals to one values[i+1].
+// after normalize, every value except last has absolute value less than its ratio
+// and all values have same sign.
+func normalizeChain(values []*int64, ratios []int64) (err error) {
+	for i, r := range ratios {
+		*values[i+1], err = addInt(*values[i+1], *values[i]/r)
+		if err != nil {
+			return
+		}
+		*values[i] %= r
+	}
+
+	var sign int64
+	for i := len(values) - 1; i >= 0; i-- {
+		if *values[i] > 0 {
+			sign = 1
+			break
+		}
+		if *values[i] < 0 {
+			sign = -1
+			break
+		}
+	}
+	for i, r := range ratios {
+		if *values[i]*sign < 0 {
+			*values[i] += sign * r
+			*values[i+1] -= sign
+		}
+	}
+	return
+}
+
+// Normalize returns canonical form of d.
+// Overflowed units are carried into larger unit when the carry is allowed (see NormalizeOptionCarry),
+// units that connected by allowed carries are folded into same sign.
+// If all components have same sign, result use Negative flag with non-negative fields,
+// otherwise result has signed fields and no Negative flag.
+func (d Duration) Normalize(options ...NormalizeOption) (ret Duration, err error) {
+	var opts = &NormalizeOptions{
+		Carry: CarrySeconds | CarryMinutes | CarryMonths,
+	}
+	for _, i := range options {
+		i(opts)
+	}
+
+	ret, err = d.signed()
+	if err != nil {
+		return Duration{}, err
+	}
+
+	var values = []*int64{&ret.Nanoseconds, &ret.Seconds, &ret.Minutes, &ret.Hours, &ret.Days, &ret.Weeks}
+	var ratios = []int64{int64(time.Second), 60, 60, 24, 7}
+	var carries = []Carry{0, CarrySeconds, CarryMinutes, CarryHours, CarryDays}
+	var start int
+	for i, c := range carries {
+		if !opts.Carry.Has(c) {
+			err = normalizeChain(values[start:i+1], ratios[start:i])
+			if err != nil {
+				return Duration{}, err
+			}
+			start = i + 1
+		}
+	}
+	err = normalizeChain(values[start:], ratios[start:])
+	if err != nil {
+		return Duration{}, err
+	}
+	if opts.Carry.Has(CarryMonths) {
+		err = normalizeChain([]*int64{&ret.Months, &ret.Years}, []int64{12})
+		if err != nil {
+			return Duration{}, err
+		}
+	}
+
+	var positive, negative bool
+	for _, v := range []int64{ret.Years, ret.Months, ret.Weeks, ret.Days, ret.Hours, ret.Minutes, ret.Seconds, ret.Nanoseconds} {
+		positive = positive || v > 0
+		negative = negative || v < 0
+	}
+	if negative && !positive {
+		ret, err = ret.Neg().signed()
+		if err != nil {
+			return Duration{}, err
+		}
+		ret.Negative = true
+	}
+	return
+}
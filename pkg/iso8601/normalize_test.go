@@ -0,0 +1,105 @@
+package iso8601
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationNormalize(t *testing.T) {
+	for _, c := range []struct {
+		duration Duration
+		options  []NormalizeOption
+		expected Duration
+		err      error
+	}{
+		{duration: Duration{}, expected: Duration{}},
+		{duration: Duration{Negative: true}, expected: Duration{}},
+		{duration: Duration{Minutes: 90}, expected: Duration{Hours: 1, Minutes: 30}},
+		{duration: Duration{Hours: 1, Minutes: 30}, expected: Duration{Hours: 1, Minutes: 30}},
+		{duration: Duration{Seconds: 3661}, expected: Duration{Hours: 1, Minutes: 1, Seconds: 1}},
+		{duration: Duration{Seconds: 61, Nanoseconds: -5e8}, expected: Duration{Minutes: 1, Nanoseconds: 5e8}},
+		{duration: Duration{Months: 14}, expected: Duration{Years: 1, Months: 2}},
+		{duration: Duration{Years: 1, Months: -1}, expected: Duration{Months: 11}},
+		{duration: Duration{Hours: 36}, expected: Duration{Hours: 36}},
+		{duration: Duration{Days: 10}, expected: Duration{Days: 10}},
+		{duration: Duration{Minutes: 90, Negative: true}, expected: Duration{Hours: 1, Minutes: 30, Negative: true}},
+		{duration: Duration{Hours: -1, Minutes: 30}, expected: Duration{Minutes: 30, Negative: true}},
+		{duration: Duration{Days: -1, Negative: true}, expected: Duration{Days: 1}},
+		{duration: Duration{Days: 1, Hours: -1}, expected: Duration{Days: 1, Hours: -1}},
+		{duration: Duration{Days: -1, Hours: -1}, expected: Duration{Days: 1, Hours: 1, Negative: true}},
+		{
+			duration: Duration{Days: 1, Hours: -1},
+			options:  []NormalizeOption{NormalizeOptionNominalDay()},
+			expected: Duration{Hours: 23},
+		},
+		{
+			duration: Duration{Hours: 36},
+			options:  []NormalizeOption{NormalizeOptionNominalDay()},
+			expected: Duration{Days: 1, Hours: 12},
+		},
+		{
+			duration: Duration{Days: 10},
+			options:  []NormalizeOption{NormalizeOptionNominalDay()},
+			expected: Duration{Weeks: 1, Days: 3},
+		},
+		{
+			duration: Duration{Hours: 170},
+			options:  []NormalizeOption{NormalizeOptionNominalDay()},
+			expected: Duration{Weeks: 1, Hours: 2},
+		},
+		{
+			duration: Duration{Minutes: 90, Months: 14},
+			options:  []NormalizeOption{NormalizeOptionCarry(CarrySeconds)},
+			expected: Duration{Minutes: 90, Months: 14},
+		},
+		{
+			duration: Duration{Seconds: 90, Nanoseconds: 1e9},
+			options:  []NormalizeOption{NormalizeOptionCarry(0)},
+			expected: Duration{Seconds: 91},
+		},
+		{
+			duration: Duration{Hours: 25},
+			options:  []NormalizeOption{NormalizeOptionCarry(CarryHours)},
+			expected: Duration{Days: 1, Hours: 1},
+		},
+		{
+			duration: Duration{Hours: maxInt64, Minutes: 60},
+			err:      ErrOverflow,
+		},
+		{
+			duration: Duration{Years: minInt64},
+			err:      ErrOverflow,
+		},
+	} {
+		t.Run(c.duration.String(), func(t *testing.T) {
+			v, err := c.duration.Normalize(c.options...)
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+}
+
+func TestDurationNormalizeEqual(t *testing.T) {
+	a, err := ParseDuration("PT90M")
+	require.NoError(t, err)
+	b, err := ParseDuration("PT1H30M")
+	require.NoError(t, err)
+	a, err = a.Normalize()
+	require.NoError(t, err)
+	b, err = b.Normalize()
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+	assert.Equal(t, "PT1H30M", a.String())
+}
+
+func BenchmarkDurationNormalize(b *testing.B) {
+	x := Duration{Years: 1, Months: 23, Weeks: 34, Days: 56, Hours: 78, Minutes: 90, Seconds: 12, Nanoseconds: 345678900}
+	for i := 0; i < b.N; i++ {
+		_, err := x.Normalize()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package iso8601
+
+import (
+	"time"
+)
+
+// OrdinalDate is iso8601 ordinal date (e.g. 2026-290).
+type OrdinalDate struct {
+	Year int
+	// Day of year in range [1, 366].
+	Day int
+}
+
+// NewOrdinalDate returns ordinal date of t in t's location.
+func NewOrdinalDate(t time.Time) OrdinalDate {
+	return OrdinalDate{Year: t.Year(), Day: t.YearDay()}
+}
+
+// daysInYear returns number of days in year, 366 for leap year.
+func daysInYear(year int) int {
+	return time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+// Time returns start of the day in loc.
+func (o OrdinalDate) Time(loc *time.Location) time.Time {
+	return time.Date(o.Year, 1, o.Day, 0, 0, 0, 0, loc)
+}
+
+// AppendFormat append o to b in extended format (2026-290),
+// or basic format (2026290) with FormatOptionBasic.
+func (o OrdinalDate) AppendFormat(b []byte, options ...FormatOption) []byte {
+	var opts FormatOptions
+	if len(options) > 0 {
+		opts = newFormatOptions(options)
+	}
+	return o.appendFormat(b, opts)
+}
+
+func (o OrdinalDate) appendFormat(b []byte, opts FormatOptions) []byte {
+	b = appendInt(b, int64(o.Year), 4)
+	if !opts.Basic {
+		b = append(b, '-')
+	}
+	return appendInt(b, int64(o.Day), 3)
+}
+
+func (o OrdinalDate) String() string {
+	return string(o.AppendFormat(make([]byte, 0, 8)))
+}
+
+// ordinalDate consumes day of year after year.
+func (p *timeParser) ordinalDate(year int) (ret OrdinalDate, ok bool) {
+	ret.Year = year
+	ret.Day, ok = p.fixed(3, 1, daysInYear(year))
+	return
+}
+
+// ParseOrdinalDate parse iso8601 ordinal date
+// in extended (2026-290) or basic (2026290) format.
+//
+// Returned error is ErrInvalidTime.
+func ParseOrdinalDate(s string) (ret OrdinalDate, err error) {
+	var p = timeParser{s: s}
+	var year, ok = p.fixed(4, 0, 9999)
+	if ok {
+		if p.peek() == '-' {
+			p.i++
+		}
+		ret, ok = p.ordinalDate(year)
+	}
+	if ok && p.i != len(s) {
+		ok = p.unexpected(p.i)
+	}
+	if !ok {
+		return OrdinalDate{}, newErrInvalidTime(s, p.offset, p.length, p.reason)
+	}
+	return
+}
@@ -0,0 +1,84 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrdinalDate(t *testing.T) {
+	for _, c := range []struct {
+		t        time.Time
+		expected OrdinalDate
+		s        string
+	}{
+		{t: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), expected: OrdinalDate{Year: 2026, Day: 290}, s: "2026-290"},
+		{t: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), expected: OrdinalDate{Year: 2026, Day: 1}, s: "2026-001"},
+		{t: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), expected: OrdinalDate{Year: 2026, Day: 365}, s: "2026-365"},
+		{t: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), expected: OrdinalDate{Year: 2024, Day: 366}, s: "2024-366"},
+		{t: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), expected: OrdinalDate{Year: 2024, Day: 61}, s: "2024-061"},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			assert.Equal(t, c.expected, NewOrdinalDate(c.t))
+			assert.Equal(t, c.t, c.expected.Time(time.UTC))
+			assert.Equal(t, c.s, c.expected.String())
+			v, err := ParseOrdinalDate(c.s)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+	assert.Equal(t, "2026290", string(OrdinalDate{Year: 2026, Day: 290}.AppendFormat(nil, FormatOptionBasic())))
+	assert.Equal(t, "-0001-365", NewOrdinalDate(time.Date(-1, 12, 31, 0, 0, 0, 0, time.UTC)).String())
+	assert.Equal(t, "-0001365", string(OrdinalDate{Year: -1, Day: 365}.AppendFormat(nil, FormatOptionBasic())))
+	v, err := ParseOrdinalDate("2026290")
+	require.NoError(t, err)
+	assert.Equal(t, OrdinalDate{Year: 2026, Day: 290}, v)
+}
+
+func TestParseOrdinalDateError(t *testing.T) {
+	for _, c := range []struct {
+		s   string
+		err error
+	}{
+		{s: "2026-366", err: ErrInvalidTime{String: "2026-366", Offset: 5, Token: "366", Reason: ReasonOutOfRange}},
+		{s: "2024-367", err: ErrInvalidTime{String: "2024-367", Offset: 5, Token: "367", Reason: ReasonOutOfRange}},
+		{s: "2026-000", err: ErrInvalidTime{String: "2026-000", Offset: 5, Token: "000", Reason: ReasonOutOfRange}},
+		{s: "2026-29", err: ErrInvalidTime{String: "2026-29", Offset: 7, Token: "", Reason: ReasonUnexpectedEnd}},
+		{s: "2026-2900", err: ErrInvalidTime{String: "2026-2900", Offset: 8, Token: "0", Reason: ReasonUnexpectedCharacter}},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseOrdinalDate(c.s)
+			assert.Equal(t, c.err, err)
+			assert.Equal(t, OrdinalDate{}, v)
+		})
+	}
+}
+
+func TestParseTimeOrdinalDate(t *testing.T) {
+	var plus1 = time.FixedZone("", 3600)
+	for _, c := range []struct {
+		s        string
+		expected time.Time
+	}{
+		{s: "2026-290", expected: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{s: "2026290", expected: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{s: "2024-366T23:59:59Z", expected: time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC)},
+		{s: "2026290T1000+01", expected: time.Date(2026, 10, 17, 10, 0, 0, 0, plus1)},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseTime(c.s)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+	_, err := ParseTime("2026-366")
+	assert.Equal(t, ErrInvalidTime{String: "2026-366", Offset: 5, Token: "366", Reason: ReasonOutOfRange}, err)
+}
+
+func TestFormatTimeOrdinalDate(t *testing.T) {
+	var v = time.Date(2026, 10, 17, 10, 0, 0, 5e8, time.FixedZone("", 3600))
+	assert.Equal(t, "2026-290T10:00:00.5+01:00", FormatTime(v, FormatOptionOrdinalDate()))
+	assert.Equal(t, "2026290T100000,5+0100", FormatTime(v, FormatOptionOrdinalDate(), FormatOptionBasic(), FormatOptionComma()))
+}
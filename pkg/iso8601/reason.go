@@ -0,0 +1,79 @@
+package iso8601
+
+// Reason describe why a string can not be parsed.
+type Reason int
+
+const (
+	// ReasonUnknown means reason is not specified.
+	ReasonUnknown Reason = iota
+	// ReasonUnexpectedCharacter means a character not allowed at the position.
+	ReasonUnexpectedCharacter
+	// ReasonUnexpectedEnd means string ended too early.
+	ReasonUnexpectedEnd
+	// ReasonMissingDesignator means a designator (e.g. 'P' or 'D') is expected.
+	ReasonMissingDesignator
+	// ReasonUnknownDesignator means designator is not known at the position.
+	ReasonUnknownDesignator
+	// ReasonMissingDigits means number has no digits.
+	ReasonMissingDigits
+	// ReasonFractionNotLast means component with fraction is not the last component.
+	ReasonFractionNotLast
+	// ReasonOverflow means value of component overflowed.
+	ReasonOverflow
+	// ReasonOutOfRange means value of component is out of its range.
+	ReasonOutOfRange
+	// ReasonSign means sign is not allowed at the position.
+	ReasonSign
+	// ReasonNoComponent means there is no component.
+	ReasonNoComponent
+	// ReasonRepeatedDesignator means a designator occurs more than once.
+	ReasonRepeatedDesignator
+	// ReasonOutOfOrder means designator occurs after a designator of smaller unit.
+	ReasonOutOfOrder
+	// ReasonEmptyTimeSection means no component after designator 'T'.
+	ReasonEmptyTimeSection
+	// ReasonWeeksCombined means weeks is combined with other components.
+	ReasonWeeksCombined
+	// ReasonMissingZone means time zone designator is required.
+	ReasonMissingZone
+	// ReasonMissingTime means interval has neither start nor end time.
+	ReasonMissingTime
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonUnexpectedCharacter:
+		return "unexpected character"
+	case ReasonUnexpectedEnd:
+		return "unexpected end"
+	case ReasonMissingDesignator:
+		return "missing designator"
+	case ReasonUnknownDesignator:
+		return "unknown designator"
+	case ReasonMissingDigits:
+		return "missing digits"
+	case ReasonFractionNotLast:
+		return "fraction not in last component"
+	case ReasonOverflow:
+		return "overflow"
+	case ReasonOutOfRange:
+		return "out of range"
+	case ReasonSign:
+		return "sign not allowed"
+	case ReasonNoComponent:
+		return "no component"
+	case ReasonRepeatedDesignator:
+		return "repeated designator"
+	case ReasonOutOfOrder:
+		return "designator out of order"
+	case ReasonEmptyTimeSection:
+		return "empty time section"
+	case ReasonWeeksCombined:
+		return "weeks combined with other components"
+	case ReasonMissingZone:
+		return "missing time zone"
+	case ReasonMissingTime:
+		return "missing time"
+	}
+	return "unknown reason"
+}
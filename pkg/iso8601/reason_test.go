@@ -0,0 +1,37 @@
+package iso8601
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReasonString(t *testing.T) {
+	for _, c := range []struct {
+		reason   Reason
+		expected string
+	}{
+		{reason: ReasonUnknown, expected: "unknown reason"},
+		{reason: ReasonUnexpectedCharacter, expected: "unexpected character"},
+		{reason: ReasonUnexpectedEnd, expected: "unexpected end"},
+		{reason: ReasonMissingDesignator, expected: "missing designator"},
+		{reason: ReasonUnknownDesignator, expected: "unknown designator"},
+		{reason: ReasonMissingDigits, expected: "missing digits"},
+		{reason: ReasonFractionNotLast, expected: "fraction not in last component"},
+		{reason: ReasonOverflow, expected: "overflow"},
+		{reason: ReasonOutOfRange, expected: "out of range"},
+		{reason: ReasonSign, expected: "sign not allowed"},
+		{reason: ReasonNoComponent, expected: "no component"},
+		{reason: ReasonRepeatedDesignator, expected: "repeated designator"},
+		{reason: ReasonOutOfOrder, expected: "designator out of order"},
+		{reason: ReasonEmptyTimeSection, expected: "empty time section"},
+		{reason: ReasonWeeksCombined, expected: "weeks combined with other components"},
+		{reason: ReasonMissingZone, expected: "missing time zone"},
+		{reason: ReasonMissingTime, expected: "missing time"},
+		{reason: Reason(-1), expected: "unknown reason"},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			assert.Equal(t, c.expected, c.reason.String())
+		})
+	}
+}
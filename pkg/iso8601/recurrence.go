@@ -0,0 +1,370 @@
+package iso8601
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// ErrNoOccurrence returned when recurrence has no occurrence that matches query.
+var ErrNoOccurrence = errors.New("iso8601: no occurrence")
+
+// Recurrence is iso8601 recurring time interval (e.g. R5/2026-01-01T09:00:00Z/P1W).
+//
+// Occurrences are computed from the anchor (start or end of Interval)
+// by multiplied duration, so month end anchor does not drift
+// (e.g. R/2026-01-31/P1M occurs on Jan 31, Mar 3, Mar 31 with MonthEndOverflow,
+// or Jan 31, Feb 28, Mar 31 with MonthEndClamp).
+type Recurrence struct {
+	// Count of occurrences, -1 means unbounded.
+	Count int
+	// Interval of first occurrence.
+	// Occurrences go backwards from End for duration/end form,
+	// duration of start/end form is Between(Start, End).
+	Interval Interval
+}
+
+// ParseRecurrence parse iso8601 recurring time interval
+// in R[n]/<interval> form, interval is parsed by ParseInterval with options,
+// n is omitted for unbounded recurrence.
+//
+// Returned error is ErrInvalidInterval.
+func ParseRecurrence(s string, options ...ParseTimeOption) (ret Recurrence, err error) {
+	if s == "" || s[0] != 'R' {
+		err = newErrInvalidInterval(s, 0, 0, ReasonMissingDesignator)
+		return
+	}
+	var count, rem, e = leadingInt(s[1:])
+	if e != nil {
+		err = newErrInvalidInterval(s, 1, leadingDigits(s[1:]), ReasonOverflow)
+		return
+	}
+	var offset = len(s) - len(rem)
+	if offset == 1 {
+		count = -1
+	} else if int64(int(count)) != count {
+		err = newErrInvalidInterval(s, 1, offset-1, ReasonOverflow)
+		return
+	}
+	if rem == "" || rem[0] != '/' {
+		err = newErrInvalidInterval(s, offset, 0, ReasonUnexpectedEnd)
+		if rem != "" {
+			err = newErrInvalidInterval(s, offset, 1, ReasonUnexpectedCharacter)
+		}
+		return
+	}
+	offset++
+	ret.Interval, err = ParseInterval(s[offset:], options...)
+	if e, ok := err.(ErrInvalidInterval); ok {
+		return Recurrence{}, newErrInvalidInterval(s, offset+e.Offset, len(e.Token), e.Reason)
+	}
+	ret.Count = int(count)
+	return
+}
+
+// MustParseRecurrence execute ParseRecurrence and panic if error.
+func MustParseRecurrence(s string, options ...ParseTimeOption) Recurrence {
+	var ret, err = ParseRecurrence(s, options...)
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+// AppendFormat append r to b, options are used for Interval.AppendFormat.
+func (r Recurrence) AppendFormat(b []byte, options ...FormatOption) []byte {
+	b = append(b, 'R')
+	if r.Count >= 0 {
+		b = strconv.AppendInt(b, int64(r.Count), 10)
+	}
+	b = append(b, '/')
+	return r.Interval.AppendFormat(b, options...)
+}
+
+func (r Recurrence) String() string {
+	return string(r.AppendFormat(make([]byte, 0, 64)))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Recurrence) MarshalText() ([]byte, error) {
+	return r.AppendFormat(make([]byte, 0, 64)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (r *Recurrence) UnmarshalText(data []byte) error {
+	var v, err = ParseRecurrence(string(data))
+	if err != nil {
+		return err
+	}
+	*r = v
+	return nil
+}
+
+// step returns duration between occurrences.
+func (r Recurrence) step() Duration {
+	var i = r.Interval
+	if i.HasStart() && i.HasEnd() {
+		return Between(i.Start, i.End)
+	}
+	return i.Duration
+}
+
+// offset returns anchor plus step multiplied by n,
+// step goes backwards for duration/end form.
+func (r Recurrence) offset(step Duration, n int, options []AddOption) (time.Time, error) {
+	var d, err = step.Mul(int64(n))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !r.Interval.HasStart() {
+		return d.Neg().AddTo(r.Interval.End, options...)
+	}
+	return d.AddTo(r.Interval.Start, options...)
+}
+
+// Occurrence returns start and end time of nth (0-based) occurrence,
+// n is not checked against Count.
+// Returns ErrMissingTime when Interval has neither start nor end time.
+func (r Recurrence) Occurrence(n int, options ...AddOption) (start, end time.Time, err error) {
+	if !r.Interval.HasStart() && !r.Interval.HasEnd() {
+		err = ErrMissingTime
+		return
+	}
+	var step = r.step()
+	start, err = r.offset(step, n, options)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err = r.offset(step, n+1, options)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !r.Interval.HasStart() {
+		start, end = end, start
+	}
+	return
+}
+
+// maxEstimatedSteps limits step count estimated by float,
+// so it can be converted to int64 without overflow.
+const maxEstimatedSteps = 1 << 62
+
+// floorSteps returns largest k that anchor plus k steps is at or before t,
+// k may be negative and is not limited by Count.
+//
+// k is estimated in largest calendar unit of step
+// (months for years and months, days for weeks and days, nanoseconds otherwise),
+// then adjusted with calendar arithmetic, so it takes constant steps
+// regardless of distance between anchor and t.
+func floorSteps(step Duration, anchor, t time.Time, options []AddOption) (k int64, err error) {
+	var d Duration
+	d, err = step.signed()
+	if err != nil {
+		return
+	}
+	var months = float64(d.Years)*12 + float64(d.Months)
+	var days = float64(d.Weeks)*7 + float64(d.Days)
+	var clock = float64(d.Hours)*float64(time.Hour) +
+		float64(d.Minutes)*float64(time.Minute) +
+		float64(d.Seconds)*float64(time.Second) +
+		float64(d.Nanoseconds)
+	t = t.In(anchor.Location())
+	// elapsed and size are measured in same unit.
+	var elapsed, size float64
+	switch {
+	case months != 0:
+		elapsed = float64(t.Year()-anchor.Year())*12 + float64(t.Month()-anchor.Month())
+		size = months + (days*float64(Day)+clock)/float64(Month)
+	case days != 0:
+		elapsed = float64(civilDays(t) - civilDays(anchor))
+		size = days + clock/float64(Day)
+	default:
+		elapsed = float64(t.Unix()-anchor.Unix())*1e9 + float64(t.Nanosecond()-anchor.Nanosecond())
+		size = clock
+	}
+	if size <= 0 {
+		return 0, ErrNoOccurrence
+	}
+	var estimated = math.Floor(elapsed / size)
+	if math.Abs(estimated) > maxEstimatedSteps {
+		return 0, ErrOverflow
+	}
+	k = int64(estimated)
+	var at = func(k int64) (time.Time, error) {
+		var d, err = step.Mul(k)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return d.AddTo(anchor, options...)
+	}
+	var v time.Time
+	for {
+		v, err = at(k)
+		if err != nil {
+			return
+		}
+		if !v.After(t) {
+			break
+		}
+		k--
+	}
+	for {
+		v, err = at(k + 1)
+		if err != nil {
+			return
+		}
+		if v.After(t) {
+			break
+		}
+		k++
+	}
+	return
+}
+
+// query returns index of last occurrence that starts at or before t,
+// and index of first occurrence that starts after t,
+// index is -1 when there is no such occurrence.
+func (r Recurrence) query(t time.Time, options []AddOption) (previous, next int, err error) {
+	var i = r.Interval
+	if !i.HasStart() && !i.HasEnd() {
+		return -1, -1, ErrMissingTime
+	}
+	var bounded = r.Count >= 0
+	var anchor = i.Start
+	if !i.HasStart() {
+		anchor = i.End
+	}
+	var k64 int64
+	k64, err = floorSteps(r.step(), anchor, t, options)
+	if err == nil && int64(int(k64)) != k64 {
+		err = ErrOverflow
+	}
+	if err != nil {
+		return -1, -1, err
+	}
+	var k = int(k64)
+	if i.HasStart() {
+		// occurrence n starts at anchor plus n steps
+		previous, next = k, k+1
+		if bounded && previous >= r.Count {
+			previous = r.Count - 1
+		}
+		if previous < 0 {
+			previous = -1
+		}
+		if next < 0 {
+			next = 0
+		}
+		if bounded && next >= r.Count {
+			next = -1
+		}
+		return
+	}
+	// occurrence n starts at anchor minus n+1 steps
+	previous, next = -k-1, -k-2
+	if previous < 0 {
+		previous = 0
+	}
+	if bounded && previous >= r.Count {
+		previous = -1
+	}
+	if bounded && next >= r.Count {
+		next = r.Count - 1
+	}
+	if next < 0 {
+		next = -1
+	}
+	return
+}
+
+// Next returns first occurrence that starts after t, respecting Count.
+// Returns ErrNoOccurrence when there is no such occurrence.
+//
+// It takes constant steps regardless of distance between t and the anchor,
+// options are used for Duration.AddTo.
+func (r Recurrence) Next(t time.Time, options ...AddOption) (index int, start, end time.Time, err error) {
+	_, index, err = r.query(t, options)
+	return r.occurrenceAt(index, err, options)
+}
+
+// Previous returns last occurrence that starts at or before t, respecting Count.
+// Returns ErrNoOccurrence when there is no such occurrence.
+//
+// It takes constant steps regardless of distance between t and the anchor,
+// options are used for Duration.AddTo.
+func (r Recurrence) Previous(t time.Time, options ...AddOption) (index int, start, end time.Time, err error) {
+	index, _, err = r.query(t, options)
+	return r.occurrenceAt(index, err, options)
+}
+
+func (r Recurrence) occurrenceAt(index int, err error, options []AddOption) (int, time.Time, time.Time, error) {
+	if err == nil && index < 0 {
+		err = ErrNoOccurrence
+	}
+	if err != nil {
+		return -1, time.Time{}, time.Time{}, err
+	}
+	var start, end time.Time
+	start, end, err = r.Occurrence(index, options...)
+	if err != nil {
+		return -1, time.Time{}, time.Time{}, err
+	}
+	return index, start, end, nil
+}
+
+// RecurrenceIterator iterates occurrences of Recurrence, like bufio.Scanner:
+//
+//	var it = r.Iterator()
+//	for it.Next() {
+//		start, end := it.Occurrence()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type RecurrenceIterator struct {
+	r          Recurrence
+	opts       []AddOption
+	index      int
+	start, end time.Time
+	err        error
+}
+
+// Iterator returns iterator over occurrences, options are used for Duration.AddTo.
+// Occurrences of duration/end form are in reverse chronological order.
+func (r Recurrence) Iterator(options ...AddOption) *RecurrenceIterator {
+	return &RecurrenceIterator{r: r, opts: options, index: -1}
+}
+
+// Next advances to next occurrence,
+// returns false when no more occurrence or error occurred.
+func (it *RecurrenceIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	var next = it.index + 1
+	if it.r.Count >= 0 && next >= it.r.Count {
+		return false
+	}
+	var start, end, err = it.r.Occurrence(next, it.opts...)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.index, it.start, it.end = next, start, end
+	return true
+}
+
+// Index returns 0-based index of current occurrence.
+func (it *RecurrenceIterator) Index() int {
+	return it.index
+}
+
+// Occurrence returns start and end time of current occurrence.
+func (it *RecurrenceIterator) Occurrence() (start, end time.Time) {
+	return it.start, it.end
+}
+
+// Err returns error occurred during iteration.
+func (it *RecurrenceIterator) Err() error {
+	return it.err
+}
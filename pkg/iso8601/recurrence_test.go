@@ -0,0 +1,213 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	var plus1 = time.FixedZone("", 3600)
+	for _, c := range []struct {
+		s         string
+		expected  Recurrence
+		formatted string
+	}{
+		{
+			s:         "R5/2026-01-01T09:00Z/P1W",
+			expected:  Recurrence{Count: 5, Interval: Interval{Start: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), Duration: Duration{Weeks: 1}, Form: IntervalFormStartDuration}},
+			formatted: "R5/2026-01-01T09:00:00Z/P1W",
+		},
+		{
+			s:         "R/2026-01-01T09:00:00+01:00/P1M",
+			expected:  Recurrence{Count: -1, Interval: Interval{Start: time.Date(2026, 1, 1, 9, 0, 0, 0, plus1), Duration: Duration{Months: 1}, Form: IntervalFormStartDuration}},
+			formatted: "R/2026-01-01T09:00:00+01:00/P1M",
+		},
+		{
+			s:         "R3/P1D/2026-01-10T00:00Z",
+			expected:  Recurrence{Count: 3, Interval: Interval{End: time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC), Duration: Duration{Days: 1}, Form: IntervalFormDurationEnd}},
+			formatted: "R3/P1D/2026-01-10T00:00:00Z",
+		},
+		{
+			s:         "R0/2026-01-01T00:00Z/2026-01-02T00:00Z",
+			expected:  Recurrence{Count: 0, Interval: Interval{Start: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), Form: IntervalFormStartEnd}},
+			formatted: "R0/2026-01-01T00:00:00Z/2026-01-02T00:00:00Z",
+		},
+		{
+			s:         "R2/PT1H",
+			expected:  Recurrence{Count: 2, Interval: Interval{Duration: Duration{Hours: 1}, Form: IntervalFormDuration}},
+			formatted: "R2/PT1H",
+		},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseRecurrence(c.s)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, v)
+			assert.Equal(t, c.formatted, v.String())
+			data, err := v.MarshalText()
+			require.NoError(t, err)
+			var r Recurrence
+			require.NoError(t, r.UnmarshalText(data))
+			assert.Equal(t, v, r)
+		})
+	}
+}
+
+func TestParseRecurrenceError(t *testing.T) {
+	for _, c := range []struct {
+		s   string
+		err error
+	}{
+		{s: "", err: ErrInvalidInterval{String: "", Offset: 0, Token: "", Reason: ReasonMissingDesignator}},
+		{s: "P1D", err: ErrInvalidInterval{String: "P1D", Offset: 0, Token: "", Reason: ReasonMissingDesignator}},
+		{s: "R5", err: ErrInvalidInterval{String: "R5", Offset: 2, Token: "", Reason: ReasonUnexpectedEnd}},
+		{s: "R5x/P1D", err: ErrInvalidInterval{String: "R5x/P1D", Offset: 2, Token: "x", Reason: ReasonUnexpectedCharacter}},
+		{s: "R99999999999999999999/P1D", err: ErrInvalidInterval{String: "R99999999999999999999/P1D", Offset: 1, Token: "99999999999999999999", Reason: ReasonOverflow}},
+		{s: "R5/2026-01-01/P1X", err: ErrInvalidInterval{String: "R5/2026-01-01/P1X", Offset: 16, Token: "X", Reason: ReasonUnknownDesignator}},
+		{s: "R5/P1D/P1D", err: ErrInvalidInterval{String: "R5/P1D/P1D", Offset: 7, Token: "P1D", Reason: ReasonMissingTime}},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseRecurrence(c.s)
+			assert.Equal(t, c.err, err)
+			assert.Equal(t, Recurrence{}, v)
+		})
+	}
+}
+
+func collectOccurrences(t *testing.T, it *RecurrenceIterator, limit int) (ret []time.Time) {
+	for len(ret) < limit && it.Next() {
+		assert.Equal(t, len(ret), it.Index())
+		var start, _ = it.Occurrence()
+		ret = append(ret, start)
+	}
+	require.NoError(t, it.Err())
+	return
+}
+
+func TestRecurrenceIterator(t *testing.T) {
+	var date = func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	for _, c := range []struct {
+		s        string
+		options  []AddOption
+		expected []time.Time
+	}{
+		{s: "R3/2026-01-01/P1W", expected: []time.Time{date(2026, 1, 1), date(2026, 1, 8), date(2026, 1, 15)}},
+		{s: "R0/2026-01-01/P1W"},
+		{s: "R/2026-01-31/P1M", expected: []time.Time{date(2026, 1, 31), date(2026, 3, 3), date(2026, 3, 31), date(2026, 5, 1)}},
+		{
+			s:        "R/2026-01-31/P1M",
+			options:  []AddOption{AddOptionMonthEnd(MonthEndClamp)},
+			expected: []time.Time{date(2026, 1, 31), date(2026, 2, 28), date(2026, 3, 31), date(2026, 4, 30)},
+		},
+		{s: "R3/P1D/2026-01-10", expected: []time.Time{date(2026, 1, 9), date(2026, 1, 8), date(2026, 1, 7)}},
+		{s: "R2/2026-01-01/2026-01-03", expected: []time.Time{date(2026, 1, 1), date(2026, 1, 3)}},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			var it = MustParseRecurrence(c.s).Iterator(c.options...)
+			assert.Equal(t, c.expected, collectOccurrences(t, it, 4))
+		})
+	}
+
+	var it = MustParseRecurrence("R2/PT1H").Iterator()
+	assert.False(t, it.Next())
+	assert.Equal(t, ErrMissingTime, it.Err())
+}
+
+func TestRecurrenceOccurrence(t *testing.T) {
+	start, end, err := MustParseRecurrence("R/2026-01-31T09:00Z/P1M").Occurrence(1, AddOptionMonthEnd(MonthEndClamp))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 3, 31, 9, 0, 0, 0, time.UTC), end)
+
+	start, end, err = MustParseRecurrence("R/P1M/2026-03-31T09:00Z").Occurrence(1, AddOptionMonthEnd(MonthEndClamp))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC), end)
+}
+
+func TestRecurrenceNextPrevious(t *testing.T) {
+	var date = func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 8, 0, 0, 0, time.UTC)
+	}
+	for _, c := range []struct {
+		s        string
+		t        time.Time
+		options  []AddOption
+		previous time.Time
+		next     time.Time
+	}{
+		{s: "R/2026-01-05T08:00Z/P1W", t: date(2026, 10, 18), previous: date(2026, 10, 12), next: date(2026, 10, 19)},
+		{s: "R/2026-01-05T08:00Z/P1W", t: date(2026, 10, 12), previous: date(2026, 10, 12), next: date(2026, 10, 19)},
+		{s: "R/2026-01-05T08:00Z/P1W", t: date(2025, 1, 1), next: date(2026, 1, 5)},
+		{s: "R3/2026-01-05T08:00Z/P1W", t: date(2026, 10, 18), previous: date(2026, 1, 19)},
+		{s: "R3/2026-01-05T08:00Z/P1W", t: date(2026, 1, 12), previous: date(2026, 1, 12), next: date(2026, 1, 19)},
+		{s: "R0/2026-01-05T08:00Z/P1W", t: date(2026, 1, 12)},
+		{s: "R/2000-01-31T08:00Z/P1M", t: date(2026, 2, 27), previous: date(2026, 1, 31), next: date(2026, 3, 3)},
+		{s: "R/2000-01-31T08:00Z/P1M", t: date(2026, 3, 3), previous: date(2026, 3, 3), next: date(2026, 3, 31)},
+		{s: "R/2000-01-31T08:00Z/P1M", t: date(2026, 2, 27), options: []AddOption{AddOptionMonthEnd(MonthEndClamp)}, previous: date(2026, 1, 31), next: date(2026, 2, 28)},
+		{s: "R/2000-02-29T08:00Z/P1Y", t: date(2026, 3, 1), options: []AddOption{AddOptionMonthEnd(MonthEndClamp)}, previous: date(2026, 2, 28), next: date(2027, 2, 28)},
+		{s: "R/2000-01-01T08:00Z/PT1S", t: time.Date(2026, 10, 18, 1, 2, 3, 500, time.UTC), previous: time.Date(2026, 10, 18, 1, 2, 3, 0, time.UTC), next: time.Date(2026, 10, 18, 1, 2, 4, 0, time.UTC)},
+		{s: "R/1900-01-01T00:00Z/PT0.000000001S", t: time.Date(2026, 10, 18, 1, 2, 3, 500, time.UTC), previous: time.Date(2026, 10, 18, 1, 2, 3, 500, time.UTC), next: time.Date(2026, 10, 18, 1, 2, 3, 501, time.UTC)},
+		{s: "R/2026-01-01T08:00Z/P300Y", t: date(2026, 10, 18), previous: date(2026, 1, 1), next: date(2326, 1, 1)},
+		{s: "R/1000-01-01T08:00Z/P500Y", t: date(2026, 10, 18), previous: date(2000, 1, 1), next: date(2500, 1, 1)},
+		{s: "R/0001-01-01T08:00Z/P1D", t: date(2026, 10, 18).Add(time.Hour), previous: date(2026, 10, 18), next: date(2026, 10, 19)},
+		{s: "R/P300Y/2026-01-01T08:00Z", t: date(2026, 10, 18), previous: date(1726, 1, 1)},
+		{s: "R/P1D/2026-01-10T08:00Z", t: date(2025, 6, 1).Add(time.Hour), previous: date(2025, 6, 1), next: date(2025, 6, 2)},
+		{s: "R/P1D/2026-01-10T08:00Z", t: date(2026, 10, 18), previous: date(2026, 1, 9)},
+		{s: "R3/P1D/2026-01-10T08:00Z", t: date(2025, 6, 1), next: date(2026, 1, 7)},
+		{s: "R3/P1D/2026-01-10T08:00Z", t: date(2026, 1, 8), previous: date(2026, 1, 8), next: date(2026, 1, 9)},
+	} {
+		t.Run(c.s+"@"+FormatTime(c.t), func(t *testing.T) {
+			var r = MustParseRecurrence(c.s)
+			_, start, _, err := r.Previous(c.t, c.options...)
+			if c.previous.IsZero() {
+				assert.Equal(t, ErrNoOccurrence, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, c.previous, start)
+			_, start, _, err = r.Next(c.t, c.options...)
+			if c.next.IsZero() {
+				assert.Equal(t, ErrNoOccurrence, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, c.next, start)
+		})
+	}
+}
+
+func TestRecurrenceNextMatchesIterator(t *testing.T) {
+	for _, s := range []string{
+		"R20/2026-01-31T09:00Z/P1M",
+		"R20/2026-01-31T09:00Z/P1M1DT1H",
+		"R20/P1M/2026-01-31T09:00Z",
+		"R20/2026-01-01T00:00Z/2026-01-03T12:00Z",
+	} {
+		t.Run(s, func(t *testing.T) {
+			var r = MustParseRecurrence(s)
+			var it = r.Iterator()
+			for it.Next() {
+				var start, end = it.Occurrence()
+				index, gotStart, gotEnd, err := r.Previous(start)
+				require.NoError(t, err)
+				assert.Equal(t, it.Index(), index)
+				assert.Equal(t, start, gotStart)
+				assert.Equal(t, end, gotEnd)
+				index, gotStart, _, err = r.Next(start.Add(-1))
+				require.NoError(t, err)
+				assert.Equal(t, it.Index(), index)
+				assert.Equal(t, start, gotStart)
+			}
+			require.NoError(t, it.Err())
+		})
+	}
+	_, _, _, err := MustParseRecurrence("R/PT1H").Next(time.Now())
+	assert.Equal(t, ErrMissingTime, err)
+	_, _, _, err = MustParseRecurrence("R/2026-01-01T00:00Z/PT0S").Next(time.Now())
+	assert.Equal(t, ErrNoOccurrence, err)
+}
@@ -0,0 +1,108 @@
+package iso8601
+
+import (
+	"fmt"
+	"time"
+)
+
+// floorMod returns v modulo n that has same sign as n.
+func floorMod(v, n int64) int64 {
+	var ret = v % n
+	if ret != 0 && (ret < 0) != (n < 0) {
+		ret += n
+	}
+	return ret
+}
+
+// alignStep returns calendar boundary at or before t for step,
+// aligned by largest unit of step:
+// years since year 0, months since year 0 (so P3M is quarters),
+// weeks since a Monday, days since unix epoch,
+// or midnight of t for steps that only have hours, minutes, seconds.
+func alignStep(t time.Time, step Duration) time.Time {
+	var year, month, day = t.Date()
+	var loc = t.Location()
+	switch {
+	case step.Years != 0:
+		return time.Date(year-int(floorMod(int64(year), step.Years)), 1, 1, 0, 0, 0, 0, loc)
+	case step.Months != 0:
+		var months = floorMod(int64(year)*12+int64(month)-1, step.Months)
+		return time.Date(year, month-time.Month(months), 1, 0, 0, 0, 0, loc)
+	case step.Weeks != 0:
+		// 1970-01-05 is Monday
+		var days = floorMod(civilDays(t)-4, step.Weeks*7)
+		return time.Date(year, month, day-int(days), 0, 0, 0, 0, loc)
+	case step.Days != 0:
+		var days = floorMod(civilDays(t), step.Days)
+		return time.Date(year, month, day-int(days), 0, 0, 0, 0, loc)
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// isPositiveStep reports whether d is not negative in any component
+// and has at least one positive component.
+func isPositiveStep(d Duration) bool {
+	if d.Negative {
+		return false
+	}
+	var positive bool
+	for _, v := range [...]int64{d.Years, d.Months, d.Weeks, d.Days, d.Hours, d.Minutes, d.Seconds, d.Nanoseconds} {
+		if v < 0 {
+			return false
+		}
+		if v > 0 {
+			positive = true
+		}
+	}
+	return positive
+}
+
+// Split returns resolved i split at calendar boundaries of step in loc,
+// first and last part are clipped to i, empty interval returns nil.
+// nil loc means location of start.
+//
+// Boundaries are aligned by largest unit of step (e.g. P1D at midnight,
+// P1W at Monday, P3M at quarters, PT1H at hours from midnight of start),
+// and computed from the aligned boundary with Duration.AddTo,
+// so they are on wall clock across DST changes.
+//
+// Returns error wraps ErrOutOfRange when step is not positive,
+// or has any negative component.
+func (i Interval) Split(step Duration, loc *time.Location) (ret []Interval, err error) {
+	if !isPositiveStep(step) {
+		return nil, fmt.Errorf("%w: step %s is not positive", ErrOutOfRange, step)
+	}
+	var start, end, ok = i.bounds()
+	if !ok {
+		return
+	}
+	if loc == nil {
+		loc = start.Location()
+	}
+	start, end = start.In(loc), end.In(loc)
+	var anchor = alignStep(start, step)
+	var k int64
+	k, err = floorSteps(step, anchor, start, nil)
+	if err != nil {
+		return nil, err
+	}
+	for start.Before(end) {
+		k++
+		var d Duration
+		d, err = step.Mul(k)
+		if err != nil {
+			return nil, err
+		}
+		var next time.Time
+		next, err = d.AddTo(anchor)
+		if err != nil {
+			return nil, err
+		}
+		if next.After(end) {
+			next = end
+		}
+		ret = append(ret, newStartEnd(start, next))
+		start = next
+	}
+	return
+}
@@ -0,0 +1,177 @@
+package iso8601
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntervalSplit(t *testing.T) {
+	var newYork, err = time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	var plus8 = time.FixedZone("", 8*3600)
+	for _, c := range []struct {
+		name     string
+		interval string
+		step     Duration
+		loc      *time.Location
+		expected []string
+	}{
+		{
+			name:     "day",
+			interval: "2026-01-30T22:00Z/2026-02-02T03:00Z",
+			step:     Duration{Days: 1},
+			expected: []string{
+				"2026-01-30T22:00:00Z/2026-01-31T00:00:00Z",
+				"2026-01-31T00:00:00Z/2026-02-01T00:00:00Z",
+				"2026-02-01T00:00:00Z/2026-02-02T00:00:00Z",
+				"2026-02-02T00:00:00Z/2026-02-02T03:00:00Z",
+			},
+		},
+		{
+			name:     "day in location",
+			interval: "2026-01-30T22:00Z/2026-01-31T22:00Z",
+			step:     Duration{Days: 1},
+			loc:      plus8,
+			expected: []string{
+				"2026-01-31T06:00:00+08:00/2026-02-01T00:00:00+08:00",
+				"2026-02-01T00:00:00+08:00/2026-02-01T06:00:00+08:00",
+			},
+		},
+		{
+			name:     "month",
+			interval: "2026-01-30T22:00Z/2026-03-02T03:00Z",
+			step:     Duration{Months: 1},
+			expected: []string{
+				"2026-01-30T22:00:00Z/2026-02-01T00:00:00Z",
+				"2026-02-01T00:00:00Z/2026-03-01T00:00:00Z",
+				"2026-03-01T00:00:00Z/2026-03-02T03:00:00Z",
+			},
+		},
+		{
+			name:     "quarter",
+			interval: "2026-02-15T00:00Z/2026-08-01T00:00Z",
+			step:     Duration{Months: 3},
+			expected: []string{
+				"2026-02-15T00:00:00Z/2026-04-01T00:00:00Z",
+				"2026-04-01T00:00:00Z/2026-07-01T00:00:00Z",
+				"2026-07-01T00:00:00Z/2026-08-01T00:00:00Z",
+			},
+		},
+		{
+			name:     "iso week",
+			interval: "2026-10-14T12:00Z/2026-10-28T00:00Z",
+			step:     Duration{Weeks: 1},
+			expected: []string{
+				"2026-10-14T12:00:00Z/2026-10-19T00:00:00Z",
+				"2026-10-19T00:00:00Z/2026-10-26T00:00:00Z",
+				"2026-10-26T00:00:00Z/2026-10-28T00:00:00Z",
+			},
+		},
+		{
+			name:     "year",
+			interval: "2025-06-01T00:00Z/2027-01-01T00:00Z",
+			step:     Duration{Years: 1},
+			expected: []string{
+				"2025-06-01T00:00:00Z/2026-01-01T00:00:00Z",
+				"2026-01-01T00:00:00Z/2027-01-01T00:00:00Z",
+			},
+		},
+		{
+			name:     "long step",
+			interval: "2026-01-01T00:00Z/2200-01-01T00:00Z",
+			step:     Duration{Years: 300},
+			expected: []string{
+				"2026-01-01T00:00:00Z/2100-01-01T00:00:00Z",
+				"2100-01-01T00:00:00Z/2200-01-01T00:00:00Z",
+			},
+		},
+		{
+			name:     "hour",
+			interval: "2026-01-01T10:30Z/PT2H",
+			step:     Duration{Hours: 1},
+			expected: []string{
+				"2026-01-01T10:30:00Z/2026-01-01T11:00:00Z",
+				"2026-01-01T11:00:00Z/2026-01-01T12:00:00Z",
+				"2026-01-01T12:00:00Z/2026-01-01T12:30:00Z",
+			},
+		},
+		{
+			name:     "inside one bucket",
+			interval: "2026-01-01T10:30Z/PT2H",
+			step:     Duration{Days: 1},
+			expected: []string{
+				"2026-01-01T10:30:00Z/2026-01-01T12:30:00Z",
+			},
+		},
+		{
+			name:     "dst start day",
+			interval: "2026-03-07T00:00-05:00/2026-03-10T00:00-04:00",
+			step:     Duration{Days: 1},
+			loc:      newYork,
+			expected: []string{
+				"2026-03-07T00:00:00-05:00/2026-03-08T00:00:00-05:00",
+				"2026-03-08T00:00:00-05:00/2026-03-09T00:00:00-04:00",
+				"2026-03-09T00:00:00-04:00/2026-03-10T00:00:00-04:00",
+			},
+		},
+		{
+			name:     "dst end hour",
+			interval: "2026-11-01T00:00-04:00/2026-11-01T03:00-05:00",
+			step:     Duration{Hours: 1},
+			loc:      newYork,
+			expected: []string{
+				"2026-11-01T00:00:00-04:00/2026-11-01T01:00:00-04:00",
+				"2026-11-01T01:00:00-04:00/2026-11-01T01:00:00-05:00",
+				"2026-11-01T01:00:00-05:00/2026-11-01T02:00:00-05:00",
+				"2026-11-01T02:00:00-05:00/2026-11-01T03:00:00-05:00",
+			},
+		},
+		{
+			name:     "empty",
+			interval: "2026-01-01T00:00Z/PT0S",
+			step:     Duration{Days: 1},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var v, err = MustParseInterval(c.interval).Split(c.step, c.loc)
+			require.NoError(t, err)
+			var actual []string
+			for _, i := range v {
+				actual = append(actual, i.String())
+			}
+			assert.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestIntervalSplitError(t *testing.T) {
+	var i = MustParseInterval("2026-01-01T00:00Z/P1D")
+	_, err := i.Split(Duration{}, nil)
+	assert.True(t, errors.Is(err, ErrOutOfRange))
+	_, err = i.Split(Duration{Days: 1, Negative: true}, nil)
+	assert.True(t, errors.Is(err, ErrOutOfRange))
+	assert.EqualError(t, err, "iso8601: out of range: step -P1D is not positive")
+	_, err = i.Split(Duration{Days: 1, Hours: -1}, nil)
+	assert.True(t, errors.Is(err, ErrOutOfRange))
+}
+
+func TestAlignStep(t *testing.T) {
+	var date = func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	var v = time.Date(2026, 10, 18, 12, 34, 56, 0, time.UTC)
+	assert.Equal(t, date(2026, 1, 1), alignStep(v, Duration{Years: 1}))
+	assert.Equal(t, date(2026, 1, 1), alignStep(v, Duration{Years: 2}))
+	assert.Equal(t, date(2026, 10, 1), alignStep(v, Duration{Months: 1}))
+	assert.Equal(t, date(2026, 10, 1), alignStep(v, Duration{Months: 3}))
+	assert.Equal(t, date(2026, 7, 1), alignStep(v, Duration{Months: 6}))
+	assert.Equal(t, date(2026, 10, 12), alignStep(v, Duration{Weeks: 1}))
+	assert.Equal(t, date(2026, 10, 18), alignStep(v, Duration{Days: 1}))
+	assert.Equal(t, date(2026, 10, 18), alignStep(v, Duration{Hours: 1}))
+	assert.Equal(t, date(-1, 1, 1), alignStep(date(-1, 6, 1), Duration{Years: 1}))
+	assert.Equal(t, date(-2, 1, 1), alignStep(date(-1, 6, 1), Duration{Years: 2}))
+}
@@ -0,0 +1,435 @@
+package iso8601
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Value implements driver.Valuer, duration is stored as iso8601 string.
+func (d Duration) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan implements sql.Scanner.
+//
+// Text value can be iso8601 duration or
+// PostgreSQL interval in `postgres`, `postgres_verbose` or `sql_standard` output style
+// (e.g. `1 year 2 mons 3 days 04:05:06.5`).
+// Integer value is treated as nanoseconds like time.Duration.
+// Invalid text returns ErrInvalidDuration with position of the invalid token.
+func (d *Duration) Scan(src interface{}) (err error) {
+	var v Duration
+	switch src := src.(type) {
+	case string:
+		v, err = parseSQLDuration(src)
+	case []byte:
+		v, err = parseSQLDuration(string(src))
+	case int64:
+		v = *NewDuration(src)
+	default:
+		err = fmt.Errorf("iso8601: cannot scan %T into Duration", src)
+	}
+	if err != nil {
+		return
+	}
+	*d = v
+	return
+}
+
+// Value implements driver.Valuer.
+func (n NullDuration) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Duration.Value()
+}
+
+// Scan implements sql.Scanner, see Duration.Scan.
+func (n *NullDuration) Scan(src interface{}) error {
+	if src == nil {
+		*n = NullDuration{}
+		return nil
+	}
+	var err = n.Duration.Scan(src)
+	if err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// errSQLInterval is position of invalid token in sql interval,
+// converted to ErrInvalidDuration by parseSQLDuration.
+type errSQLInterval struct {
+	offset int
+	length int
+	reason Reason
+}
+
+func (e errSQLInterval) Error() string {
+	return "iso8601: invalid sql interval" // never printed
+}
+
+// sqlField is a space separated part of sql interval,
+// offset is byte offset of s in whole string.
+type sqlField struct {
+	s      string
+	offset int
+}
+
+// slice returns f.s[i:j] as field.
+func (f sqlField) slice(i, j int) sqlField {
+	return sqlField{s: f.s[i:j], offset: f.offset + i}
+}
+
+// errorAt returns error of byte at i, token is empty when i is end of field.
+func (f sqlField) errorAt(i int, reason Reason) errSQLInterval {
+	var length int
+	if i < len(f.s) {
+		length = 1
+	}
+	return errSQLInterval{offset: f.offset + i, length: length, reason: reason}
+}
+
+// error returns error of whole field.
+func (f sqlField) error(reason Reason) errSQLInterval {
+	return errSQLInterval{offset: f.offset, length: len(f.s), reason: reason}
+}
+
+// end returns error of end of field.
+func (f sqlField) end(reason Reason) errSQLInterval {
+	return f.errorAt(len(f.s), reason)
+}
+
+func isSQLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
+
+// sqlFields splits s at spaces like strings.Fields,
+// offset is byte offset of s in whole string.
+func sqlFields(s string, offset int) (ret []sqlField) {
+	var start = -1
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) && !isSQLSpace(s[i]) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			ret = append(ret, sqlField{s: s[start:i], offset: offset + start})
+			start = -1
+		}
+	}
+	return
+}
+
+func parseSQLDuration(s string) (ret Duration, err error) {
+	var orig = s
+	var start = len(s) - len(strings.TrimLeftFunc(s, unicode.IsSpace))
+	s = strings.TrimSpace(s)
+	if s == "" {
+		err = newErrInvalidDuration(orig, len(orig), 0, ReasonUnexpectedEnd)
+		return
+	}
+	var _, rem = leadingNegative(s)
+	switch {
+	case rem != "" && rem[0] == 'P':
+		return ParseDuration(s)
+	case s[0] == '@':
+		ret, err = parsePostgresInterval(orig, start+1, true)
+	case strings.IndexFunc(s, isLetter) >= 0:
+		ret, err = parsePostgresInterval(orig, start, false)
+	default:
+		ret, err = parseSQLStandardInterval(orig, start)
+	}
+	if err == ErrOverflow {
+		err = newErrInvalidDuration(orig, 0, len(orig), ReasonOverflow)
+	} else if e, ok := err.(errSQLInterval); ok {
+		err = newErrInvalidDuration(orig, e.offset, e.length, e.reason)
+	}
+	return
+}
+
+func isLetter(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// parseSQLNumber parse `[+-]?[0-9]+(\.[0-9]*)?` to integer and nanoseconds of fraction.
+func parseSQLNumber(f sqlField) (neg, explicit bool, v, nano int64, err error) {
+	explicit = f.s != "" && (f.s[0] == '-' || f.s[0] == '+')
+	var s string
+	neg, s = leadingNegative(f.s)
+	var index = len(f.s) - len(s)
+	var n = leadingDigits(s)
+	if n == 0 {
+		err = f.errorAt(index, ReasonMissingDigits)
+		return
+	}
+	v, _, err = leadingInt(s[:n])
+	if err != nil {
+		return
+	}
+	index += n
+	if index < len(f.s) && f.s[index] == '.' {
+		var digits = f.s[index+1:]
+		digits = digits[:leadingDigits(digits)]
+		nano = fractionOf(digits, time.Second)
+		index += 1 + len(digits)
+	}
+	if index < len(f.s) {
+		err = f.errorAt(index, ReasonUnexpectedCharacter)
+		return
+	}
+	if neg {
+		v, nano = -v, -nano
+	}
+	return
+}
+
+// parseSQLDigits parse `[0-9]+`.
+func parseSQLDigits(f sqlField) (v int64, err error) {
+	var n = leadingDigits(f.s)
+	if n == 0 {
+		if f.s != "" && (f.s[0] == '-' || f.s[0] == '+') {
+			err = f.errorAt(0, ReasonSign)
+		} else {
+			err = f.errorAt(0, ReasonMissingDigits)
+		}
+		return
+	}
+	v, _, err = leadingInt(f.s[:n])
+	if err == nil && n < len(f.s) {
+		err = f.errorAt(n, ReasonUnexpectedCharacter)
+	}
+	return
+}
+
+// parseSQLTime parse `[+-]?[0-9]+:[0-9]+(:[0-9]+(\.[0-9]*)?)?`.
+func parseSQLTime(f sqlField, ret *Duration) (neg, explicit bool, err error) {
+	explicit = f.s != "" && (f.s[0] == '-' || f.s[0] == '+')
+	var s string
+	neg, s = leadingNegative(f.s)
+	var body = f.slice(len(f.s)-len(s), len(f.s))
+	var parts []sqlField
+	var start int
+	for i := 0; i <= len(body.s); i++ {
+		if i < len(body.s) && body.s[i] != ':' {
+			continue
+		}
+		if len(parts) == 3 {
+			err = body.errorAt(start-1, ReasonUnexpectedCharacter)
+			return
+		}
+		parts = append(parts, body.slice(start, i))
+		start = i + 1
+	}
+	if len(parts) < 2 {
+		err = body.end(ReasonUnexpectedEnd)
+		return
+	}
+	ret.Hours, err = parseSQLDigits(parts[0])
+	if err != nil {
+		return
+	}
+	ret.Minutes, err = parseSQLDigits(parts[1])
+	if err != nil {
+		return
+	}
+	if len(parts) == 3 {
+		var signed bool
+		_, signed, ret.Seconds, ret.Nanoseconds, err = parseSQLNumber(parts[2])
+		if err == nil && signed {
+			err = parts[2].errorAt(0, ReasonSign)
+		}
+		if err != nil {
+			return
+		}
+	}
+	if neg {
+		ret.Hours, ret.Minutes, ret.Seconds, ret.Nanoseconds = -ret.Hours, -ret.Minutes, -ret.Seconds, -ret.Nanoseconds
+	}
+	return
+}
+
+// addClock returns d with hours, minutes, seconds and nanoseconds of o added,
+// returns ErrOverflow when any of them overflowed.
+func (d Duration) addClock(o Duration) (ret Duration, err error) {
+	ret = d
+	ret.Hours, err = addInt(ret.Hours, o.Hours)
+	if err != nil {
+		return
+	}
+	ret.Minutes, err = addInt(ret.Minutes, o.Minutes)
+	if err != nil {
+		return
+	}
+	ret.Seconds, err = addInt(ret.Seconds, o.Seconds)
+	if err != nil {
+		return
+	}
+	ret.Nanoseconds, err = addInt(ret.Nanoseconds, o.Nanoseconds)
+	return
+}
+
+// parsePostgresInterval parse interval in `postgres` or `postgres_verbose` output style
+// from s[offset:].
+// e.g. `1 year 2 mons -3 days +04:05:06.5` or `@ 1 year 2 mons -3 days 4 hours 5 mins 6.5 secs ago`
+func parsePostgresInterval(s string, offset int, verbose bool) (ret Duration, err error) {
+	var fields = sqlFields(s[offset:], offset)
+	if verbose && len(fields) > 0 && fields[len(fields)-1].s == "ago" {
+		ret.Negative = true
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) == 0 {
+		err = errSQLInterval{offset: len(s), reason: ReasonUnexpectedEnd}
+		return
+	}
+	for len(fields) > 0 {
+		var number = fields[0]
+		fields = fields[1:]
+		if strings.IndexByte(number.s, ':') >= 0 {
+			var t Duration
+			_, _, err = parseSQLTime(number, &t)
+			if err != nil {
+				return
+			}
+			ret, err = ret.addClock(t)
+			if err != nil {
+				return
+			}
+			continue
+		}
+		var v, nano int64
+		_, _, v, nano, err = parseSQLNumber(number)
+		if err != nil {
+			return
+		}
+		if len(fields) == 0 {
+			if verbose && v == 0 && nano == 0 {
+				// `@ 0`
+				continue
+			}
+			err = number.end(ReasonMissingDesignator)
+			return
+		}
+		var unit = fields[0]
+		fields = fields[1:]
+		if nano != 0 {
+			switch unit.s {
+			case "sec", "secs", "second", "seconds":
+			default:
+				// fraction only allowed for seconds
+				err = number.errorAt(strings.IndexByte(number.s, '.'), ReasonUnexpectedCharacter)
+				return
+			}
+		}
+		var field *int64
+		switch unit.s {
+		case "year", "years":
+			field = &ret.Years
+		case "mon", "mons", "month", "months":
+			field = &ret.Months
+		case "week", "weeks":
+			field = &ret.Weeks
+		case "day", "days":
+			field = &ret.Days
+		case "hour", "hours":
+			field = &ret.Hours
+		case "min", "mins", "minute", "minutes":
+			field = &ret.Minutes
+		case "sec", "secs", "second", "seconds":
+			ret, err = ret.addClock(Duration{Seconds: v, Nanoseconds: nano})
+			if err != nil {
+				return
+			}
+			continue
+		default:
+			err = unit.error(ReasonUnknownDesignator)
+			return
+		}
+		*field, err = addInt(*field, v)
+		if err != nil {
+			return
+		}
+	}
+	err = carryNano(&ret)
+	return
+}
+
+// parseSQLStandardInterval parse interval in `sql_standard` output style
+// from s[offset:].
+// e.g. `1-2`, `3 4:05:06`, `-1-2 +3 -4:05:06`
+//
+// When only first field has sign, the sign applies to all fields.
+// Each field can appear at most once, in order of year-month, day and time.
+func parseSQLStandardInterval(s string, offset int) (ret Duration, err error) {
+	var firstNeg, mixed bool
+	// order of last field: 1 for year-month, 2 for day, 3 for time
+	var lastOrder int
+	for index, f := range sqlFields(s[offset:], offset) {
+		var neg, explicit bool
+		var rem string
+		neg, rem = leadingNegative(f.s)
+		var order = 2
+		if strings.IndexByte(f.s, ':') >= 0 {
+			order = 3
+		} else if strings.IndexByte(rem, '-') >= 0 {
+			order = 1
+		}
+		if order == lastOrder {
+			err = f.error(ReasonRepeatedDesignator)
+			return
+		}
+		if order < lastOrder {
+			err = f.error(ReasonOutOfOrder)
+			return
+		}
+		lastOrder = order
+		if order == 3 {
+			neg, explicit, err = parseSQLTime(f, &ret)
+		} else {
+			explicit = f.s[0] == '-' || f.s[0] == '+'
+			var body = f.slice(len(f.s)-len(rem), len(f.s))
+			if i := strings.IndexByte(body.s, '-'); i >= 0 {
+				ret.Years, err = parseSQLDigits(body.slice(0, i))
+				if err == nil {
+					ret.Months, err = parseSQLDigits(body.slice(i+1, len(body.s)))
+				}
+				if neg {
+					ret.Years, ret.Months = -ret.Years, -ret.Months
+				}
+			} else {
+				ret.Days, err = parseSQLDigits(body)
+				if neg {
+					ret.Days = -ret.Days
+				}
+			}
+		}
+		if err != nil {
+			return
+		}
+		if index == 0 {
+			firstNeg = neg
+		} else {
+			mixed = mixed || explicit
+		}
+	}
+	if firstNeg && !mixed {
+		// sign of first field applies to all fields
+		ret, err = Duration{
+			Years:       absInt(ret.Years),
+			Months:      absInt(ret.Months),
+			Days:        absInt(ret.Days),
+			Hours:       absInt(ret.Hours),
+			Minutes:     absInt(ret.Minutes),
+			Seconds:     absInt(ret.Seconds),
+			Nanoseconds: absInt(ret.Nanoseconds),
+			Negative:    true,
+		}.signed()
+	}
+	return
+}
@@ -0,0 +1,221 @@
+package iso8601
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver returns query text as the only row,
+// and records args of last exec.
+type fakeDriver struct {
+	args []driver.Value
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return fakeConn{d}, nil
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{c.d, query}, nil
+}
+
+func (c fakeConn) Close() error {
+	return nil
+}
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s fakeStmt) Close() error {
+	return nil
+}
+
+func (s fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.args = args
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	var v driver.Value = []byte(s.query)
+	if s.query == "NULL" {
+		v = nil
+	}
+	return &fakeRows{value: v}, nil
+}
+
+type fakeRows struct {
+	value driver.Value
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"v"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+
+var testDriver = new(fakeDriver)
+
+func init() {
+	sql.Register("iso8601-fake", testDriver)
+}
+
+func TestDurationScan(t *testing.T) {
+	for _, c := range []struct {
+		src      interface{}
+		expected Duration
+		err      error
+	}{
+		{src: "P1Y2M3DT4H5M6.5S", expected: Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 5e8}},
+		{src: []byte("-PT1H"), expected: Duration{Hours: 1, Negative: true}},
+		{src: int64(3600e9), expected: Duration{Hours: 1}},
+		// postgres
+		{src: "00:00:00", expected: Duration{}},
+		{src: "1 year 2 mons 3 days 04:05:06.5", expected: Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 5e8}},
+		{src: "1 day", expected: Duration{Days: 1}},
+		{src: "-1 years -2 mons +3 days -04:05:06", expected: Duration{Years: -1, Months: -2, Days: 3, Hours: -4, Minutes: -5, Seconds: -6}},
+		{src: "-1 days +02:03:00", expected: Duration{Days: -1, Hours: 2, Minutes: 3}},
+		{src: "100:00:00.000001", expected: Duration{Hours: 100, Nanoseconds: 1e3}},
+		// postgres_verbose
+		{src: "@ 0", expected: Duration{}},
+		{src: "@ 1 year 2 mons 3 days 4 hours 5 mins 6.5 secs", expected: Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 5e8}},
+		{src: "@ 1 year 2 mons -3 days 4 hours 5 mins 6 secs ago", expected: Duration{Years: 1, Months: 2, Days: -3, Hours: 4, Minutes: 5, Seconds: 6, Negative: true}},
+		{src: "@ 1 sec", expected: Duration{Seconds: 1}},
+		{src: "@ 0.5 secs ago", expected: Duration{Nanoseconds: 5e8, Negative: true}},
+		// sql_standard
+		{src: "0", expected: Duration{}},
+		{src: "1-2", expected: Duration{Years: 1, Months: 2}},
+		{src: "-1-2", expected: Duration{Years: -1, Months: -2}},
+		{src: "3 4:05:06.5", expected: Duration{Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 5e8}},
+		{src: "-3 4:05:06", expected: Duration{Days: -3, Hours: -4, Minutes: -5, Seconds: -6}},
+		{src: "1-2 3 4:05:06", expected: Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6}},
+		{src: "-1-2 +3 -4:05:06", expected: Duration{Years: -1, Months: -2, Days: 3, Hours: -4, Minutes: -5, Seconds: -6}},
+		{src: "+1-2 -3 +4:05:06", expected: Duration{Years: 1, Months: 2, Days: -3, Hours: 4, Minutes: 5, Seconds: 6}},
+		{src: "4:05", expected: Duration{Hours: 4, Minutes: 5}},
+		// invalid
+		{src: "", err: ErrInvalidDuration{String: "", Offset: 0, Token: "", Reason: ReasonUnexpectedEnd}},
+		{src: "1 fortnight", err: ErrInvalidDuration{String: "1 fortnight", Offset: 2, Token: "fortnight", Reason: ReasonUnknownDesignator}},
+		{src: "  1 day 1 fortnight", err: ErrInvalidDuration{String: "  1 day 1 fortnight", Offset: 10, Token: "fortnight", Reason: ReasonUnknownDesignator}},
+		{src: "1.5 days", err: ErrInvalidDuration{String: "1.5 days", Offset: 1, Token: ".", Reason: ReasonUnexpectedCharacter}},
+		{src: "1 year 2", err: ErrInvalidDuration{String: "1 year 2", Offset: 8, Token: "", Reason: ReasonMissingDesignator}},
+		{src: "1x year", err: ErrInvalidDuration{String: "1x year", Offset: 1, Token: "x", Reason: ReasonUnexpectedCharacter}},
+		{src: "@", err: ErrInvalidDuration{String: "@", Offset: 1, Token: "", Reason: ReasonUnexpectedEnd}},
+		{src: "4:05:06:07", err: ErrInvalidDuration{String: "4:05:06:07", Offset: 7, Token: ":", Reason: ReasonUnexpectedCharacter}},
+		{src: "4:-5", err: ErrInvalidDuration{String: "4:-5", Offset: 2, Token: "-", Reason: ReasonSign}},
+		{src: "1--2", err: ErrInvalidDuration{String: "1--2", Offset: 2, Token: "-", Reason: ReasonSign}},
+		{src: "-", err: ErrInvalidDuration{String: "-", Offset: 1, Token: "", Reason: ReasonMissingDigits}},
+		{src: "1 2", err: ErrInvalidDuration{String: "1 2", Offset: 2, Token: "2", Reason: ReasonRepeatedDesignator}},
+		{src: "1-2 1-3", err: ErrInvalidDuration{String: "1-2 1-3", Offset: 4, Token: "1-3", Reason: ReasonRepeatedDesignator}},
+		{src: "1:00 1:00", err: ErrInvalidDuration{String: "1:00 1:00", Offset: 5, Token: "1:00", Reason: ReasonRepeatedDesignator}},
+		{src: "3 1-2", err: ErrInvalidDuration{String: "3 1-2", Offset: 2, Token: "1-2", Reason: ReasonOutOfOrder}},
+		{src: "4:05 3", err: ErrInvalidDuration{String: "4:05 3", Offset: 5, Token: "3", Reason: ReasonOutOfOrder}},
+		{src: "P1X", err: ErrInvalidDuration{String: "P1X", Offset: 2, Token: "X", Reason: ReasonUnknownDesignator}},
+		// overflow
+		{src: "9223372036854775807 years 1 year", err: ErrInvalidDuration{String: "9223372036854775807 years 1 year", Token: "9223372036854775807 years 1 year", Reason: ReasonOverflow}},
+		{src: "-9223372036854775807 days -2 days", err: ErrInvalidDuration{String: "-9223372036854775807 days -2 days", Token: "-9223372036854775807 days -2 days", Reason: ReasonOverflow}},
+		{src: "9223372036854775807 hours 1:00:00", err: ErrInvalidDuration{String: "9223372036854775807 hours 1:00:00", Token: "9223372036854775807 hours 1:00:00", Reason: ReasonOverflow}},
+		{src: "@ 9223372036854775807 secs 0.5 secs 0.5 secs", err: ErrInvalidDuration{String: "@ 9223372036854775807 secs 0.5 secs 0.5 secs", Token: "@ 9223372036854775807 secs 0.5 secs 0.5 secs", Reason: ReasonOverflow}},
+		{src: "9223372036854775808 years", err: ErrInvalidDuration{String: "9223372036854775808 years", Token: "9223372036854775808 years", Reason: ReasonOverflow}},
+	} {
+		var name = fmt.Sprint(c.src)
+		if b, ok := c.src.([]byte); ok {
+			name = string(b)
+		}
+		t.Run(name, func(t *testing.T) {
+			var v Duration
+			err := v.Scan(c.src)
+			require.Equal(t, c.err, err)
+			assert.Equal(t, c.expected, v)
+			if e, ok := c.err.(ErrInvalidDuration); ok && e.Reason == ReasonOverflow {
+				assert.True(t, errors.Is(err, ErrOverflow))
+			}
+		})
+	}
+
+	var v = Duration{Days: 1}
+	assert.EqualError(t, v.Scan(nil), "iso8601: cannot scan <nil> into Duration")
+	assert.EqualError(t, v.Scan(1.5), "iso8601: cannot scan float64 into Duration")
+	assert.Equal(t, Duration{Days: 1}, v)
+}
+
+func TestDurationValue(t *testing.T) {
+	v, err := Duration{Days: 1, Hours: 1}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "P1DT1H", v)
+
+	v, err = NullDuration{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = NullDuration{Duration: Duration{Days: 1}, Valid: true}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "P1D", v)
+}
+
+func TestNullDurationScan(t *testing.T) {
+	var v = NullDuration{Duration: Duration{Days: 1}, Valid: true}
+	require.NoError(t, v.Scan(nil))
+	assert.Equal(t, NullDuration{}, v)
+
+	require.NoError(t, v.Scan("1 day"))
+	assert.Equal(t, NullDuration{Duration: Duration{Days: 1}, Valid: true}, v)
+
+	v = NullDuration{}
+	assert.Equal(t, ErrInvalidDuration{String: "x", Offset: 0, Token: "x", Reason: ReasonMissingDigits}, v.Scan("x"))
+	assert.False(t, v.Valid)
+}
+
+func TestDurationSQLDriver(t *testing.T) {
+	db, err := sql.Open("iso8601-fake", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	var d Duration
+	err = db.QueryRow("1 year 2 mons 3 days 04:05:06.5").Scan(&d)
+	require.NoError(t, err)
+	assert.Equal(t, Duration{Years: 1, Months: 2, Days: 3, Hours: 4, Minutes: 5, Seconds: 6, Nanoseconds: 5e8}, d)
+
+	var n NullDuration
+	err = db.QueryRow("NULL").Scan(&n)
+	require.NoError(t, err)
+	assert.Equal(t, NullDuration{}, n)
+
+	err = db.QueryRow("NULL").Scan(&d)
+	assert.Error(t, err)
+
+	_, err = db.Exec("INSERT", Duration{Hours: 1, Negative: true}, NullDuration{}, &Duration{Days: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []driver.Value{"-PT1H", nil, "P1D"}, testDriver.args)
+}
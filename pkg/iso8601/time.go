@@ -1,15 +1,361 @@
 package iso8601
 
-import "time"
+import (
+	"time"
+)
 
-// ParseTime from string.
-// a shortcut for time.Parse(time.RFC3339Nano, s)
-func ParseTime(s string) (time.Time, error) {
-	return time.Parse(time.RFC3339Nano, s)
+// ErrInvalidTime returned when parse time failed.
+type ErrInvalidTime struct {
+	String string
+	// Offset is byte offset of Token in String.
+	Offset int
+	// Token is the offending part of String, may be empty (e.g. when string ended).
+	Token  string
+	Reason Reason
+}
+
+func newErrInvalidTime(s string, offset, length int, reason Reason) ErrInvalidTime {
+	return ErrInvalidTime{
+		String: s,
+		Offset: offset,
+		Token:  s[offset : offset+length],
+		Reason: reason,
+	}
+}
+
+func (err ErrInvalidTime) Error() string {
+	return parseErrorMessage("time", err.String, err.Offset, err.Token, err.Reason)
+}
+
+// ParseTimeOptions for ParseTime.
+type ParseTimeOptions struct {
+	// Location used when time zone designator is omitted, nil means time.UTC.
+	Location *time.Location
+	// RequireZone reject value without time zone designator.
+	RequireZone bool
+}
+
+// ParseTimeOption mutate ParseTimeOptions.
+type ParseTimeOption func(opts *ParseTimeOptions)
+
+// ParseTimeOptionLocation set location used when time zone designator is omitted,
+// defaults to time.UTC.
+func ParseTimeOptionLocation(loc *time.Location) ParseTimeOption {
+	return func(opts *ParseTimeOptions) {
+		opts.Location = loc
+	}
+}
+
+// ParseTimeOptionRequireZone reject value without time zone designator,
+// includes date without time.
+func ParseTimeOptionRequireZone() ParseTimeOption {
+	return func(opts *ParseTimeOptions) {
+		opts.RequireZone = true
+	}
+}
+
+func newParseTimeOptions(options []ParseTimeOption) ParseTimeOptions {
+	var opts = new(ParseTimeOptions)
+	for _, i := range options {
+		i(opts)
+	}
+	return *opts
+}
+
+// timeParser consumes s from index i,
+// methods return false on error and record error position.
+type timeParser struct {
+	s string
+	i int
+
+	offset, length int
+	reason         Reason
+}
+
+func (p *timeParser) fail(offset, length int, reason Reason) bool {
+	p.offset, p.length, p.reason = offset, length, reason
+	return false
+}
+
+// unexpected fails at index i.
+func (p *timeParser) unexpected(i int) bool {
+	if i >= len(p.s) {
+		return p.fail(len(p.s), 0, ReasonUnexpectedEnd)
+	}
+	return p.fail(i, 1, ReasonUnexpectedCharacter)
+}
+
+// peek returns next byte, or 0 at end.
+func (p *timeParser) peek() byte {
+	if p.i < len(p.s) {
+		return p.s[p.i]
+	}
+	return 0
+}
+
+// fixed consumes n digits as integer in range [min, max].
+func (p *timeParser) fixed(n, min, max int) (v int, ok bool) {
+	var d = leadingDigits(p.s[p.i:])
+	if d < n {
+		return 0, p.unexpected(p.i + d)
+	}
+	for _, c := range p.s[p.i : p.i+n] {
+		v = v*10 + int(c-'0')
+	}
+	if v < min || v > max {
+		return 0, p.fail(p.i, n, ReasonOutOfRange)
+	}
+	p.i += n
+	return v, true
+}
+
+// date consumes calendar date
+// in extended (YYYY-MM-DD, YYYY-MM), basic (YYYYMMDD) or year only (YYYY) format,
+// week date (YYYY-Www-D, YYYYWwwD, YYYY-Www, YYYYWww)
+// or ordinal date (YYYY-DDD, YYYYDDD),
+// complete is false for reduced precision.
+func (p *timeParser) date() (year int, month time.Month, day int, complete, ok bool) {
+	month, day = 1, 1
+	year, ok = p.fixed(4, 0, 9999)
+	if !ok {
+		return
+	}
+	var m int
+	var extended = p.peek() == '-'
+	if extended {
+		p.i++
+	}
+	if c := p.peek(); c == 'W' || c == 'w' {
+		p.i++
+		var w WeekDate
+		w, complete, ok = p.weekDate(year, extended)
+		if ok {
+			year, month, day = w.Time(time.UTC).Date()
+		}
+		return
+	}
+	if leadingDigits(p.s[p.i:]) == 3 {
+		var o OrdinalDate
+		o, ok = p.ordinalDate(year)
+		if ok {
+			year, month, day = o.Time(time.UTC).Date()
+		}
+		complete = ok
+		return
+	}
+	switch c := p.peek(); {
+	case extended:
+		m, ok = p.fixed(2, 1, 12)
+		if !ok {
+			return
+		}
+		month = time.Month(m)
+		if p.peek() != '-' {
+			return
+		}
+		p.i++
+	case c >= '0' && c <= '9':
+		m, ok = p.fixed(2, 1, 12)
+		if !ok {
+			return
+		}
+		month = time.Month(m)
+	default:
+		return
+	}
+	day, ok = p.fixed(2, 1, daysIn(year, month))
+	complete = ok
+	return
+}
+
+// clock consumes time of day after 'T'
+// in extended (hh:mm:ss, hh:mm, hh) or basic (hhmmss, hhmm) format,
+// last component can have fraction.
+func (p *timeParser) clock() (hour, min, sec, nano int, ok bool) {
+	var start = p.i
+	hour, ok = p.fixed(2, 0, 24)
+	if !ok {
+		return
+	}
+	var extended = p.peek() == ':'
+	var unit = time.Hour
+	var next = func() bool {
+		if extended {
+			if p.peek() != ':' {
+				return false
+			}
+			p.i++
+			return true
+		}
+		var c = p.peek()
+		return c >= '0' && c <= '9'
+	}
+	if next() {
+		unit = time.Minute
+		min, ok = p.fixed(2, 0, 59)
+		if !ok {
+			return
+		}
+		if next() {
+			unit = time.Second
+			sec, ok = p.fixed(2, 0, 59)
+			if !ok {
+				return
+			}
+		}
+	}
+	if c := p.peek(); c == '.' || c == ',' {
+		p.i++
+		var digits = p.s[p.i : p.i+leadingDigits(p.s[p.i:])]
+		if digits == "" {
+			return 0, 0, 0, 0, p.fail(p.i-1, 1, ReasonMissingDigits)
+		}
+		p.i += len(digits)
+		var d = time.Duration(fractionOf(digits, unit))
+		switch unit {
+		case time.Hour:
+			min = int(d / time.Minute)
+			d %= time.Minute
+			fallthrough
+		case time.Minute:
+			sec = int(d / time.Second)
+			d %= time.Second
+		}
+		nano = int(d)
+	}
+	if hour == 24 && (min != 0 || sec != 0 || nano != 0) {
+		// 24 only allowed for end of day
+		return 0, 0, 0, 0, p.fail(start, 2, ReasonOutOfRange)
+	}
+	return
+}
+
+// zone consumes time zone designator: Z, ±hh, ±hh:mm or ±hhmm.
+func (p *timeParser) zone() (loc *time.Location, ok bool) {
+	var c = p.peek()
+	if c == 'Z' || c == 'z' {
+		p.i++
+		return time.UTC, true
+	}
+	p.i++
+	var hour, min int
+	hour, ok = p.fixed(2, 0, 23)
+	if !ok {
+		return
+	}
+	if p.peek() == ':' {
+		p.i++
+		min, ok = p.fixed(2, 0, 59)
+	} else if d := p.peek(); d >= '0' && d <= '9' {
+		min, ok = p.fixed(2, 0, 59)
+	}
+	if !ok {
+		return
+	}
+	var offset = hour*3600 + min*60
+	if offset == 0 {
+		return time.UTC, true
+	}
+	if c == '-' {
+		offset = -offset
+	}
+	return time.FixedZone("", offset), true
+}
+
+// ParseTime parse iso8601 date time string without regex.
+//
+// Supported forms:
+//   - calendar date in extended (2001-02-03) or basic (20010203) format,
+//     or reduced precision (2001-02, 2001)
+//   - week date in extended (2001-W05-6) or basic (2001W056) format,
+//     or reduced precision (2001-W05) that means Monday
+//   - ordinal date in extended (2001-034) or basic (2001034) format
+//   - optional time after 'T' in extended (04:05:06) or basic (040506) format,
+//     or reduced precision (04:05, 04),
+//     last component can have fraction of any length with '.' or ',' as decimal sign,
+//     and 24:00 means end of the day.
+//   - optional time zone designator after time: Z, ±hh, ±hh:mm or ±hhmm.
+//
+// Designators T, W and Z are case insensitive.
+// Value without time zone designator is in time.UTC by default,
+// see ParseTimeOptionLocation and ParseTimeOptionRequireZone.
+// Zero offset is time.UTC, other offsets are time.FixedZone without name.
+//
+// Returned error is ErrInvalidTime.
+func ParseTime(s string, options ...ParseTimeOption) (ret time.Time, err error) {
+	var opts ParseTimeOptions
+	if len(options) > 0 {
+		opts = newParseTimeOptions(options)
+	}
+	var p = timeParser{s: s}
+	var year, month, day, complete, ok = p.date()
+	var hour, min, sec, nano int
+	var loc = opts.Location
+	var hasZone bool
+	if ok && complete {
+		if c := p.peek(); c == 'T' || c == 't' {
+			p.i++
+			hour, min, sec, nano, ok = p.clock()
+			if ok {
+				if c := p.peek(); c == 'Z' || c == 'z' || c == '+' || c == '-' {
+					hasZone = true
+					loc, ok = p.zone()
+				}
+			}
+		}
+	}
+	if ok && p.i != len(s) {
+		ok = p.unexpected(p.i)
+	}
+	if ok && !hasZone && opts.RequireZone {
+		ok = p.fail(len(s), 0, ReasonMissingZone)
+	}
+	if !ok {
+		err = newErrInvalidTime(s, p.offset, p.length, p.reason)
+		return
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return time.Date(year, month, day, hour, min, sec, nano, loc), nil
 }
 
 // FormatTime to string
-// a shortcut for string(t.AppendFormat(make([]byte, 0, 32), time.RFC3339Nano))
-func FormatTime(t time.Time) string {
-	return string(t.AppendFormat(make([]byte, 0, 32), time.RFC3339Nano))
+// a shortcut for string(t.AppendFormat(make([]byte, 0, 32), time.RFC3339Nano)),
+// use FormatOptionComma for ',' as decimal sign,
+// FormatOptionBasic for basic format (20010203T040506Z),
+// FormatOptionWeekDate for week date (2001-W05-6T04:05:06Z)
+// and FormatOptionOrdinalDate for ordinal date (2001-034T04:05:06Z).
+func FormatTime(t time.Time, options ...FormatOption) string {
+	var opts FormatOptions
+	if len(options) > 0 {
+		opts = newFormatOptions(options)
+	}
+	var b = make([]byte, 0, 32)
+	var layout = time.RFC3339Nano
+	var clock = "T15:04:05.999999999Z07:00"
+	if opts.Basic {
+		clock = "T150405.999999999Z0700"
+	}
+	switch {
+	case opts.WeekDate:
+		b = NewWeekDate(t).appendFormat(b, opts)
+		layout = clock
+	case opts.OrdinalDate:
+		b = NewOrdinalDate(t).appendFormat(b, opts)
+		layout = clock
+	case opts.Basic:
+		layout = "20060102" + clock
+	}
+	b = t.AppendFormat(b, layout)
+	if opts.Comma {
+		// fraction is the only place that '.' can occur.
+		for i, c := range b {
+			if c == '.' {
+				b[i] = ','
+				break
+			}
+		}
+	}
+	return string(b)
 }
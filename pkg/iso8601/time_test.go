@@ -9,13 +9,34 @@ import (
 )
 
 func TestParseTime(t *testing.T) {
+	var plus1 = time.FixedZone("", 3600)
+	var minus1530 = time.FixedZone("", -(15*3600 + 30*60))
 	for _, c := range []struct {
 		s        string
 		expected time.Time
 	}{
 		{s: "2001-02-03T04:05:06.07Z", expected: time.Date(2001, 2, 3, 4, 5, 6, 70e6, time.UTC)},
 		{s: "2001-02-03T04:05:06Z", expected: time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)},
-		// {s: "2001-02-03", expected: time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{s: "2001-02-03T04:05:06,07Z", expected: time.Date(2001, 2, 3, 4, 5, 6, 70e6, time.UTC)},
+		{s: "2001-02-03", expected: time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{s: "2001-02", expected: time.Date(2001, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{s: "2001", expected: time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{s: "20010203", expected: time.Date(2001, 2, 3, 0, 0, 0, 0, time.UTC)},
+		{s: "20010203T040506Z", expected: time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)},
+		{s: "20010203T0405Z", expected: time.Date(2001, 2, 3, 4, 5, 0, 0, time.UTC)},
+		{s: "20010203T04", expected: time.Date(2001, 2, 3, 4, 0, 0, 0, time.UTC)},
+		{s: "2001-02-03T04:05", expected: time.Date(2001, 2, 3, 4, 5, 0, 0, time.UTC)},
+		{s: "2001-02-03t04:05:06z", expected: time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)},
+		{s: "2001-02-03T04:05:06.123456789123Z", expected: time.Date(2001, 2, 3, 4, 5, 6, 123456789, time.UTC)},
+		{s: "2001-02-03T04.5", expected: time.Date(2001, 2, 3, 4, 30, 0, 0, time.UTC)},
+		{s: "2001-02-03T04:05,5", expected: time.Date(2001, 2, 3, 4, 5, 30, 0, time.UTC)},
+		{s: "2001-02-03T24:00:00Z", expected: time.Date(2001, 2, 4, 0, 0, 0, 0, time.UTC)},
+		{s: "2001-02-03T04:05:06+01", expected: time.Date(2001, 2, 3, 4, 5, 6, 0, plus1)},
+		{s: "2001-02-03T04:05:06+0100", expected: time.Date(2001, 2, 3, 4, 5, 6, 0, plus1)},
+		{s: "2001-02-03T04:05:06+01:00", expected: time.Date(2001, 2, 3, 4, 5, 6, 0, plus1)},
+		{s: "2001-02-03T04:05:06-15:30", expected: time.Date(2001, 2, 3, 4, 5, 6, 0, minus1530)},
+		{s: "2001-02-03T04:05:06+00:00", expected: time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC)},
+		{s: "2000-02-29", expected: time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)},
 	} {
 		t.Run(c.s, func(t *testing.T) {
 			v, err := ParseTime(c.s)
@@ -25,6 +46,67 @@ func TestParseTime(t *testing.T) {
 	}
 }
 
+func TestParseTimeError(t *testing.T) {
+	for _, c := range []struct {
+		s      string
+		offset int
+		token  string
+		reason Reason
+	}{
+		{s: "", offset: 0, reason: ReasonUnexpectedEnd},
+		{s: "200", offset: 3, reason: ReasonUnexpectedEnd},
+		{s: "2001-2-03", offset: 6, token: "-", reason: ReasonUnexpectedCharacter},
+		{s: "2001-13-01", offset: 5, token: "13", reason: ReasonOutOfRange},
+		{s: "2001-02-29", offset: 8, token: "29", reason: ReasonOutOfRange},
+		{s: "2001-00", offset: 5, token: "00", reason: ReasonOutOfRange},
+		{s: "200102", offset: 6, reason: ReasonUnexpectedEnd},
+		{s: "2001-02T04", offset: 7, token: "T", reason: ReasonUnexpectedCharacter},
+		{s: "2001-02-03T", offset: 11, reason: ReasonUnexpectedEnd},
+		{s: "2001-02-03T25", offset: 11, token: "25", reason: ReasonOutOfRange},
+		{s: "2001-02-03T24:00:01", offset: 11, token: "24", reason: ReasonOutOfRange},
+		{s: "2001-02-03T04:60", offset: 14, token: "60", reason: ReasonOutOfRange},
+		{s: "2001-02-03T04:05:", offset: 17, reason: ReasonUnexpectedEnd},
+		{s: "2001-02-03T04:0506", offset: 16, token: "0", reason: ReasonUnexpectedCharacter},
+		{s: "2001-02-03T04:05:06.Z", offset: 19, token: ".", reason: ReasonMissingDigits},
+		{s: "2001-02-03T04:05:06+1", offset: 21, reason: ReasonUnexpectedEnd},
+		{s: "2001-02-03T04:05:06+1x", offset: 21, token: "x", reason: ReasonUnexpectedCharacter},
+		{s: "2001-02-03T04:05:06+24", offset: 20, token: "24", reason: ReasonOutOfRange},
+		{s: "2001-02-03T04:05:06ZZ", offset: 20, token: "Z", reason: ReasonUnexpectedCharacter},
+		{s: "2001-02-03Z", offset: 10, token: "Z", reason: ReasonUnexpectedCharacter},
+		{s: "2001-02-03 04:05:06Z", offset: 10, token: " ", reason: ReasonUnexpectedCharacter},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			_, err := ParseTime(c.s)
+			require.Equal(t, ErrInvalidTime{String: c.s, Offset: c.offset, Token: c.token, Reason: c.reason}, err)
+		})
+	}
+	assert.EqualError(t,
+		ErrInvalidTime{String: "2001-13", Offset: 5, Token: "13", Reason: ReasonOutOfRange},
+		`iso8601: invalid time 2001-13: out of range "13" at offset 5`,
+	)
+}
+
+func TestParseTimeOptions(t *testing.T) {
+	var loc = time.FixedZone("X", 8*3600)
+	v, err := ParseTime("2001-02-03T04:05:06", ParseTimeOptionLocation(loc))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2001, 2, 3, 4, 5, 6, 0, loc), v)
+
+	v, err = ParseTime("2001-02-03T04:05:06Z", ParseTimeOptionLocation(loc))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC), v)
+
+	_, err = ParseTime("2001-02-03T04:05:06", ParseTimeOptionRequireZone())
+	assert.Equal(t, ErrInvalidTime{String: "2001-02-03T04:05:06", Offset: 19, Reason: ReasonMissingZone}, err)
+
+	_, err = ParseTime("2001-02-03", ParseTimeOptionRequireZone())
+	assert.Equal(t, ErrInvalidTime{String: "2001-02-03", Offset: 10, Reason: ReasonMissingZone}, err)
+
+	v, err = ParseTime("2001-02-03T04:05:06+08:00", ParseTimeOptionRequireZone())
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2001, 2, 3, 4, 5, 6, 0, time.FixedZone("", 8*3600)), v)
+}
+
 func BenchmarkParseTime(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, err := ParseTime("2001-02-03T04:05:06.07Z")
@@ -49,6 +131,22 @@ func TestFormatTime(t *testing.T) {
 	}
 }
 
+func TestFormatTimeComma(t *testing.T) {
+	for _, c := range []struct {
+		t        time.Time
+		expected string
+	}{
+		{t: time.Date(2001, 2, 3, 4, 5, 6, 70e6, time.UTC), expected: "2001-02-03T04:05:06,07Z"},
+		{t: time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC), expected: "2001-02-03T04:05:06Z"},
+		{t: time.Date(2001, 2, 3, 4, 5, 6, 1, time.FixedZone("", -3600)), expected: "2001-02-03T04:05:06,000000001-01:00"},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			s := FormatTime(c.t, FormatOptionComma())
+			assert.Equal(t, c.expected, s)
+		})
+	}
+}
+
 func BenchmarkFormatTime(b *testing.B) {
 	var t = time.Date(2001, 2, 3, 4, 5, 6, 70e6, time.UTC)
 	for i := 0; i < b.N; i++ {
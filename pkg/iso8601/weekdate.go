@@ -0,0 +1,127 @@
+package iso8601
+
+import (
+	"time"
+)
+
+// WeekDate is iso8601 week date (e.g. 2026-W42-5).
+// Year is the ISO week-numbering year, it can differ from calendar year
+// for days near the year boundary.
+type WeekDate struct {
+	Year int
+	// Week in range [1, 53].
+	Week int
+	// Day of week in range [1, 7], Monday is 1 and Sunday is 7.
+	// Zero means day is omitted (e.g. 2026-W42).
+	Day int
+}
+
+// NewWeekDate returns week date of t in t's location.
+func NewWeekDate(t time.Time) WeekDate {
+	var year, week = t.ISOWeek()
+	var day = int(t.Weekday())
+	if day == 0 {
+		day = 7
+	}
+	return WeekDate{Year: year, Week: week, Day: day}
+}
+
+// weeksIn returns number of weeks in ISO week-numbering year.
+func weeksIn(year int) int {
+	// December 28 is always in the last week.
+	var _, week = time.Date(year, 12, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// Time returns start of the day in loc,
+// omitted day is Monday.
+func (w WeekDate) Time(loc *time.Location) time.Time {
+	// January 4 is always in week 1.
+	var jan4 = time.Date(w.Year, 1, 4, 0, 0, 0, 0, time.UTC)
+	var weekday = int(jan4.Weekday()+6) % 7 // Monday is 0
+	var day = w.Day
+	if day == 0 {
+		day = 1
+	}
+	var y, m, d = jan4.AddDate(0, 0, (w.Week-1)*7+day-1-weekday).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// AppendFormat append w to b in extended format (2026-W42-5),
+// or basic format (2026W425) with FormatOptionBasic.
+func (w WeekDate) AppendFormat(b []byte, options ...FormatOption) []byte {
+	var opts FormatOptions
+	if len(options) > 0 {
+		opts = newFormatOptions(options)
+	}
+	return w.appendFormat(b, opts)
+}
+
+func (w WeekDate) appendFormat(b []byte, opts FormatOptions) []byte {
+	b = appendInt(b, int64(w.Year), 4)
+	if !opts.Basic {
+		b = append(b, '-')
+	}
+	b = append(b, 'W')
+	b = appendInt(b, int64(w.Week), 2)
+	if w.Day != 0 {
+		if !opts.Basic {
+			b = append(b, '-')
+		}
+		b = appendInt(b, int64(w.Day), 1)
+	}
+	return b
+}
+
+func (w WeekDate) String() string {
+	return string(w.AppendFormat(make([]byte, 0, 10)))
+}
+
+// weekDate consumes week date after 'W' of year,
+// complete is false when day is omitted.
+func (p *timeParser) weekDate(year int, extended bool) (ret WeekDate, complete, ok bool) {
+	ret.Year = year
+	ret.Week, ok = p.fixed(2, 1, weeksIn(year))
+	if !ok {
+		return
+	}
+	if extended {
+		if p.peek() != '-' {
+			return
+		}
+		p.i++
+	} else if c := p.peek(); c < '0' || c > '9' {
+		return
+	}
+	ret.Day, ok = p.fixed(1, 1, 7)
+	complete = ok
+	return
+}
+
+// ParseWeekDate parse iso8601 week date
+// in extended (2026-W42-5, 2026-W42) or basic (2026W425, 2026W42) format.
+//
+// Returned error is ErrInvalidTime.
+func ParseWeekDate(s string) (ret WeekDate, err error) {
+	var p = timeParser{s: s}
+	var year, ok = p.fixed(4, 0, 9999)
+	if ok {
+		var extended = p.peek() == '-'
+		if extended {
+			p.i++
+		}
+		if c := p.peek(); c == 'W' || c == 'w' {
+			p.i++
+			ret, _, ok = p.weekDate(year, extended)
+		} else {
+			ok = p.unexpected(p.i)
+		}
+	}
+	if ok && p.i != len(s) {
+		ok = p.unexpected(p.i)
+	}
+	if !ok {
+		return WeekDate{}, newErrInvalidTime(s, p.offset, p.length, p.reason)
+	}
+	return
+}
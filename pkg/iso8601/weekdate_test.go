@@ -0,0 +1,105 @@
+package iso8601
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWeekDate(t *testing.T) {
+	for _, c := range []struct {
+		t        time.Time
+		expected WeekDate
+	}{
+		{t: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), expected: WeekDate{Year: 2026, Week: 42, Day: 5}},
+		{t: time.Date(2008, 12, 29, 0, 0, 0, 0, time.UTC), expected: WeekDate{Year: 2009, Week: 1, Day: 1}},
+		{t: time.Date(2010, 1, 3, 0, 0, 0, 0, time.UTC), expected: WeekDate{Year: 2009, Week: 53, Day: 7}},
+		{t: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), expected: WeekDate{Year: 2026, Week: 53, Day: 5}},
+		{t: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), expected: WeekDate{Year: 2020, Week: 53, Day: 5}},
+		{t: time.Date(2027, 1, 3, 0, 0, 0, 0, time.UTC), expected: WeekDate{Year: 2026, Week: 53, Day: 7}},
+		{t: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), expected: WeekDate{Year: 2025, Week: 1, Day: 1}},
+	} {
+		t.Run(c.expected.String(), func(t *testing.T) {
+			assert.Equal(t, c.expected, NewWeekDate(c.t))
+			assert.Equal(t, c.t, c.expected.Time(time.UTC))
+		})
+	}
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local), WeekDate{Year: 2026, Week: 42}.Time(time.Local))
+}
+
+func TestWeekDateFormat(t *testing.T) {
+	for _, c := range []struct {
+		w        WeekDate
+		options  []FormatOption
+		expected string
+	}{
+		{w: WeekDate{Year: 2026, Week: 42, Day: 5}, expected: "2026-W42-5"},
+		{w: WeekDate{Year: 2026, Week: 42}, expected: "2026-W42"},
+		{w: WeekDate{Year: 2009, Week: 1, Day: 1}, options: []FormatOption{FormatOptionBasic()}, expected: "2009W011"},
+		{w: WeekDate{Year: 2009, Week: 1}, options: []FormatOption{FormatOptionBasic()}, expected: "2009W01"},
+	} {
+		t.Run(c.expected, func(t *testing.T) {
+			assert.Equal(t, c.expected, string(c.w.AppendFormat(nil, c.options...)))
+			v, err := ParseWeekDate(c.expected)
+			require.NoError(t, err)
+			assert.Equal(t, c.w, v)
+		})
+	}
+	v, err := ParseWeekDate("2026w425")
+	require.NoError(t, err)
+	assert.Equal(t, WeekDate{Year: 2026, Week: 42, Day: 5}, v)
+	assert.Equal(t, "-0001-W52-5", NewWeekDate(time.Date(-1, 12, 31, 0, 0, 0, 0, time.UTC)).String())
+}
+
+func TestParseWeekDateError(t *testing.T) {
+	for _, c := range []struct {
+		s   string
+		err error
+	}{
+		{s: "2026-W54-1", err: ErrInvalidTime{String: "2026-W54-1", Offset: 6, Token: "54", Reason: ReasonOutOfRange}},
+		{s: "2025-W53-1", err: ErrInvalidTime{String: "2025-W53-1", Offset: 6, Token: "53", Reason: ReasonOutOfRange}},
+		{s: "2026-W00", err: ErrInvalidTime{String: "2026-W00", Offset: 6, Token: "00", Reason: ReasonOutOfRange}},
+		{s: "2026-W42-8", err: ErrInvalidTime{String: "2026-W42-8", Offset: 9, Token: "8", Reason: ReasonOutOfRange}},
+		{s: "2026-W42-", err: ErrInvalidTime{String: "2026-W42-", Offset: 9, Token: "", Reason: ReasonUnexpectedEnd}},
+		{s: "2026-W425", err: ErrInvalidTime{String: "2026-W425", Offset: 8, Token: "5", Reason: ReasonUnexpectedCharacter}},
+		{s: "2026W42-5", err: ErrInvalidTime{String: "2026W42-5", Offset: 7, Token: "-", Reason: ReasonUnexpectedCharacter}},
+		{s: "2026-10-16", err: ErrInvalidTime{String: "2026-10-16", Offset: 5, Token: "1", Reason: ReasonUnexpectedCharacter}},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseWeekDate(c.s)
+			assert.Equal(t, c.err, err)
+			assert.Equal(t, WeekDate{}, v)
+		})
+	}
+}
+
+func TestParseTimeWeekDate(t *testing.T) {
+	for _, c := range []struct {
+		s        string
+		expected time.Time
+	}{
+		{s: "2026-W42-5", expected: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{s: "2026W425", expected: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)},
+		{s: "2026-W42", expected: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)},
+		{s: "2009-W01-1T10:00Z", expected: time.Date(2008, 12, 29, 10, 0, 0, 0, time.UTC)},
+		{s: "2009W537T100000Z", expected: time.Date(2010, 1, 3, 10, 0, 0, 0, time.UTC)},
+		{s: "2009-w53-7t10:00z", expected: time.Date(2010, 1, 3, 10, 0, 0, 0, time.UTC)},
+	} {
+		t.Run(c.s, func(t *testing.T) {
+			v, err := ParseTime(c.s)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, v)
+		})
+	}
+	_, err := ParseTime("2026-W42T10:00Z")
+	assert.Equal(t, ErrInvalidTime{String: "2026-W42T10:00Z", Offset: 8, Token: "T", Reason: ReasonUnexpectedCharacter}, err)
+}
+
+func TestFormatTimeWeekDate(t *testing.T) {
+	var v = time.Date(2008, 12, 29, 10, 0, 0, 5e8, time.FixedZone("", 3600))
+	assert.Equal(t, "2009-W01-1T10:00:00.5+01:00", FormatTime(v, FormatOptionWeekDate()))
+	assert.Equal(t, "2009W011T100000,5+0100", FormatTime(v, FormatOptionWeekDate(), FormatOptionBasic(), FormatOptionComma()))
+	assert.Equal(t, "20081229T100000.5+0100", FormatTime(v, FormatOptionBasic()))
+}